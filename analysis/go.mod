@@ -0,0 +1,16 @@
+module github.com/go-the-way/validator/analysis
+
+go 1.22.0
+
+require github.com/go-the-way/validator v0.0.0-20261018114903-2b5e2ae69758
+
+require (
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+	golang.org/x/text v0.3.8 // indirect
+)
+
+require (
+	github.com/billcoding/reflectx v1.0.0 // indirect
+	golang.org/x/tools v0.28.0
+)
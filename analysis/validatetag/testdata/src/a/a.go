@@ -0,0 +1,47 @@
+package a // want package:`customNames\(local\)`
+
+import (
+	"reflect"
+	"time"
+
+	_ "b"
+
+	"github.com/go-the-way/validator"
+)
+
+const local = "local"
+
+func init() {
+	new(validator.Registry).Register(local, func(reflect.Value) (bool, string) { return true, "" })
+}
+
+type Level int
+
+type T struct {
+	Name    string           `validate:"required(T) minlength(2) maxlength(8) regex(^[a-z]+$) msg(bad name)"`
+	Age     *Level           `validate:"min(1) max(9) enum(1|2|3)"`
+	Tags    []string         `validate:"size(2) dive(regex(^a))"`
+	Meta    map[string][]int `validate:"keys(length(2)) values(minsize(1) max(3))"`
+	Ptrs    []*int           `validate:"valid(T)"`
+	Any     interface{}      `validate:"min(1)"`
+	When    time.Time        `validate:"required(T)"`
+	Ext     string           `validate:"custom(ext) custom(local)"`
+	Skip    int              `validate:"-"`
+	BadMin  int              `validate:"min(abc)"`            // want `validate tag: invalid min\(abc\): strconv.ParseFloat: parsing "abc": invalid syntax`
+	Unknown int              `validate:"mni(1)"`              // want `validate tag: unknown rule mni`
+	Regex   int              `validate:"regex(^a)"`           // want `validate tag: regex does not apply to int`
+	Size    string           `validate:"arr_length(2)"`       // want `validate tag: size does not apply to string`
+	Enum    uint8            `validate:"enum(a|-1)"`          // want `validate tag: invalid enum\(a\|-1\) on uint8: strconv.ParseUint: parsing "a": invalid syntax`
+	Level   Level            `validate:"minlength(1)"`        // want `validate tag: minlength does not apply to Level`
+	Map     map[string]int   `validate:"min(1)"`              // want `validate tag: min is not supported on maps, use keys\(...\) or values\(...\)`
+	Dive    []int            `validate:"dive(min(1)) max(3)"` // want `validate tag: max is not supported on slices or arrays with dive, use dive\(...\)`
+	Keys    []int            `validate:"keys(min(1))"`        // want `validate tag: keys requires a map field, not \[\]int`
+	Values  map[int]string   `validate:"values(min(1))"`      // want `validate tag: values.min does not apply to string`
+	Valid   int              `validate:"valid(T)"`            // want `validate tag: valid does not apply to int`
+	Paren   int              `validate:"min(1"`               // want `validate tag: invalid min\(1\): column 4: unbalanced parentheses`
+	Nested  *T               `validate:"nested(maybe)"`       // want `validate tag: invalid nested\(maybe\): strconv.ParseBool: parsing "maybe": invalid syntax`
+	Bool    bool             `validate:"omitempty(true,x)"`   // want `validate tag: invalid omitempty\(true,x\): strconv.ParseBool: parsing "true,x": invalid syntax`
+	When2   time.Time        `validate:"min(1)"`              // want `validate tag: min does not apply to time.Time`
+	Addr    uintptr          `validate:"min(1)"`              // want `validate tag: min does not apply to uintptr`
+	Custom  string           `validate:"custom(missing)"`
+}
@@ -0,0 +1,11 @@
+package b
+
+import (
+	"reflect"
+
+	"github.com/go-the-way/validator"
+)
+
+func init() {
+	validator.Custom("ext", func(reflect.Value) (bool, string) { return true, "" })
+}
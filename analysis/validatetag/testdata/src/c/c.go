@@ -0,0 +1,17 @@
+package c // want package:`customNames\(\) dynamic`
+
+import (
+	"reflect"
+
+	"github.com/go-the-way/validator"
+)
+
+var name = "dynamic"
+
+func init() {
+	new(validator.Registry).Register(name, func(reflect.Value) (bool, string) { return true, "" })
+}
+
+type T struct {
+	F string `validate:"custom(anything)"`
+}
@@ -0,0 +1,18 @@
+package d // want package:`customNames\(local\)`
+
+import (
+	"reflect"
+
+	_ "b"
+
+	"github.com/go-the-way/validator"
+)
+
+func init() {
+	validator.Custom("local", func(reflect.Value) (bool, string) { return true, "" })
+}
+
+type T struct {
+	Ext     string `validate:"custom(ext) custom(local)"`
+	Missing string `validate:"custom(missing)"` // want `validate tag: custom validator missing is not registered`
+}
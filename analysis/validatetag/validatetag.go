@@ -0,0 +1,373 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validatetag defines an Analyzer checking the validate tags of struct fields at build time.
+//
+// Every validate tag is parsed with the grammar of validator.Item. The analyzer reports malformed tags,
+// unknown rules, malformed rule values, rules that do not apply to the type of their field, like regex
+// on an int field or size on a string field, and keys, values and dive on fields of other kinds.
+//
+// With the -custom flag, custom validators are reported when their name is not registered with a constant by
+// validator.Custom, validator.CustomContext, Registry.Register or Registry.RegisterContext in the package or its
+// dependencies. They are not reported when a name is registered with a variable, as it cannot be known before running.
+// The check is off by default: validators are often registered by packages the checked package does not import,
+// e.g. by the main package.
+package validatetag
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-the-way/validator"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const validatorPath = "github.com/go-the-way/validator"
+
+// Analyzer checks the validate tags of struct fields
+var Analyzer = &analysis.Analyzer{
+	Name:      "validatetag",
+	Doc:       "check validate struct tags of github.com/go-the-way/validator",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(customNames)},
+}
+
+// checkCustom enables the report of custom validators not registered by the package or its dependencies
+var checkCustom bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkCustom, "custom", false, "report custom validators not registered by the package or its dependencies")
+}
+
+// customNames is the fact of the custom validators registered by a package
+type customNames struct {
+	Names   []string
+	Dynamic bool // Dynamic reports a name registered with a variable
+}
+
+// AFact method
+func (*customNames) AFact() {}
+
+// String method
+func (f *customNames) String() string {
+	s := "customNames(" + strings.Join(f.Names, ", ") + ")"
+	if f.Dynamic {
+		s += " dynamic"
+	}
+	return s
+}
+
+// registerFuncs are the funcs and methods of validator registering custom validators
+var registerFuncs = map[string]bool{"Custom": true, "CustomContext": true, "Register": true, "RegisterContext": true}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	registered := registeredNames(pass, insp)
+	if len(registered.Names) > 0 || registered.Dynamic {
+		pass.ExportPackageFact(registered)
+	}
+	c := &checker{pass: pass, customs: make(map[string]bool)}
+	for _, fact := range pass.AllPackageFacts() {
+		if names, ok := fact.Fact.(*customNames); ok {
+			for _, name := range names.Names {
+				c.customs[name] = true
+			}
+			c.dynamic = c.dynamic || names.Dynamic
+		}
+	}
+	for _, name := range registered.Names {
+		c.customs[name] = true
+	}
+	c.dynamic = c.dynamic || registered.Dynamic
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			s, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			tag, ok := reflect.StructTag(s).Lookup("validate")
+			if !ok || tag == "-" {
+				continue
+			}
+			c.tag = field.Tag
+			c.check(tag, pass.TypesInfo.TypeOf(field.Type), "")
+		}
+	})
+	return nil, nil
+}
+
+// registeredNames return the names of the custom validators registered by the package
+func registeredNames(pass *analysis.Pass, insp *inspector.Inspector) *customNames {
+	names := new(customNames)
+	seen := make(map[string]bool)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != validatorPath || !registerFuncs[fn.Name()] || len(call.Args) == 0 {
+			return
+		}
+		if tv := pass.TypesInfo.Types[call.Args[0]]; tv.Value != nil && tv.Value.Kind() == constant.String {
+			if name := constant.StringVal(tv.Value); !seen[name] {
+				seen[name] = true
+				names.Names = append(names.Names, name)
+			}
+		} else {
+			names.Dynamic = true
+		}
+	})
+	sort.Strings(names.Names)
+	return names
+}
+
+// checker checks the validate tag of a field
+type checker struct {
+	pass    *analysis.Pass
+	tag     *ast.BasicLit
+	customs map[string]bool
+	dynamic bool
+}
+
+func (c *checker) report(format string, args ...interface{}) {
+	c.pass.Reportf(c.tag.Pos(), "validate tag: "+format, args...)
+}
+
+// check checks a tag validating values of type typ, prefix names the element of keys, values and dive tags
+func (c *checker) check(tag string, typ types.Type, prefix string) {
+	rules, err := validator.ParseRules(tag)
+	if err != nil {
+		if tagErr, ok := err.(*validator.TagError); ok {
+			c.report("invalid %s%s(%s): %v", prefix, tagErr.Rule, tagErr.Text, tagErr.Err)
+		}
+	}
+	dive := false
+	for _, r := range rules {
+		dive = dive || (r.Name == "dive" && r.Value != "")
+	}
+	for _, r := range rules {
+		if !validator.IsRuleName(r.Name) {
+			c.report("unknown rule %s%s", prefix, r.Name)
+			continue
+		}
+		if r.Value == "" {
+			continue
+		}
+		switch r.Name {
+		case "msg":
+		case "custom":
+			if checkCustom && !c.dynamic && !c.customs[r.Value] {
+				c.report("custom validator %s is not registered", r.Value)
+			}
+		case "nested":
+			if _, err := strconv.ParseBool(r.Value); err != nil {
+				c.report("invalid %snested(%s): %v", prefix, r.Value, err)
+			}
+		case "keys", "values", "dive":
+			elem, ok := elemType(typ, r.Name)
+			if !ok {
+				kinds := "map"
+				if r.Name == "dive" {
+					kinds = "slice or array"
+				}
+				c.report("%s%s requires a %s field, not %s", prefix, r.Name, kinds, c.typeString(typ))
+				continue
+			}
+			c.check(r.Value, elem, prefix+r.Name+".")
+		default:
+			rule := validator.NewRule(r.Name, r.Value)
+			if err := rule.Err(); err != nil {
+				c.report("invalid %s%s(%s): %v", prefix, r.Name, r.Value, err)
+			} else if typ != nil {
+				c.checkType(rule, typ, dive, prefix)
+			}
+		}
+	}
+}
+
+// checkType reports a rule that does not apply to values of type typ
+func (c *checker) checkType(rule validator.Rule, typ types.Type, dive bool, prefix string) {
+	name, u := rule.Name(), deref(typ)
+	if isInterface(u) {
+		// dynamic values are validated by their concrete value
+		return
+	}
+	if validator.IsElemRule(name) {
+		switch u.(type) {
+		case *types.Map:
+			c.report("%s%s is not supported on maps, use keys(...) or values(...)", prefix, name)
+			return
+		case *types.Slice, *types.Array:
+			if dive {
+				c.report("%s%s is not supported on slices or arrays with dive, use dive(...)", prefix, name)
+				return
+			}
+		}
+	}
+	if !applies(name, typ) {
+		c.report("%s%s does not apply to %s", prefix, name, c.typeString(typ))
+		return
+	}
+	if name == "enum" {
+		if b, ok := leaf(typ).(*types.Basic); ok && b.Info()&types.IsInteger != 0 {
+			for _, option := range strings.Split(rule.Param(), "|") {
+				var err error
+				if b.Info()&types.IsUnsigned != 0 {
+					_, err = strconv.ParseUint(option, 10, 64)
+				} else {
+					_, err = strconv.ParseInt(option, 10, 64)
+				}
+				if err != nil {
+					c.report("invalid %senum(%s) on %s: %v", prefix, rule.Param(), c.typeString(typ), err)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *checker) typeString(typ types.Type) string {
+	if typ == nil {
+		return "unknown type"
+	}
+	return types.TypeString(typ, types.RelativeTo(c.pass.Pkg))
+}
+
+// applies reports whether the rule validates values of type typ instead of always passing, see validator.RuleKinds
+func applies(name string, typ types.Type) bool {
+	kinds, elems := validator.RuleKinds(name)
+	if kinds == nil {
+		return true
+	}
+	for {
+		u := typ.Underlying()
+		kind := kindOf(u)
+		for _, k := range kinds {
+			if k == kind {
+				return true
+			}
+		}
+		switch e := u.(type) {
+		case *types.Pointer:
+			typ = e.Elem()
+		case *types.Slice:
+			if !elems {
+				return false
+			}
+			typ = e.Elem()
+		case *types.Array:
+			if !elems {
+				return false
+			}
+			typ = e.Elem()
+		default:
+			// dynamic values are validated by their concrete value
+			return isInterface(u)
+		}
+	}
+}
+
+// basicKinds are the reflect kinds of the typed basic kinds
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool: reflect.Bool, types.String: reflect.String, types.UnsafePointer: reflect.UnsafePointer,
+	types.Int: reflect.Int, types.Int8: reflect.Int8, types.Int16: reflect.Int16, types.Int32: reflect.Int32, types.Int64: reflect.Int64,
+	types.Uint: reflect.Uint, types.Uint8: reflect.Uint8, types.Uint16: reflect.Uint16, types.Uint32: reflect.Uint32,
+	types.Uint64: reflect.Uint64, types.Uintptr: reflect.Uintptr,
+	types.Float32: reflect.Float32, types.Float64: reflect.Float64, types.Complex64: reflect.Complex64, types.Complex128: reflect.Complex128,
+}
+
+// kindOf return the reflect kind of the values of the underlying type u
+func kindOf(u types.Type) reflect.Kind {
+	switch u := u.(type) {
+	case *types.Basic:
+		return basicKinds[u.Kind()]
+	case *types.Pointer:
+		return reflect.Ptr
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Array:
+		return reflect.Array
+	case *types.Map:
+		return reflect.Map
+	case *types.Chan:
+		return reflect.Chan
+	case *types.Signature:
+		return reflect.Func
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Interface:
+		return reflect.Interface
+	}
+	return reflect.Invalid
+}
+
+// elemType return the type of the keys, values or dive elements of typ, unknown types have unknown elements
+func elemType(typ types.Type, name string) (types.Type, bool) {
+	if typ == nil {
+		return nil, true
+	}
+	switch u := deref(typ).(type) {
+	case *types.Map:
+		if name == "keys" {
+			return u.Key(), true
+		}
+		return u.Elem(), name == "values"
+	case *types.Slice:
+		return u.Elem(), name == "dive"
+	case *types.Array:
+		return u.Elem(), name == "dive"
+	case *types.Interface:
+		return nil, true
+	}
+	return nil, false
+}
+
+// deref return the underlying type reached through the pointers of typ
+func deref(typ types.Type) types.Type {
+	u := typ.Underlying()
+	for {
+		p, ok := u.(*types.Pointer)
+		if !ok {
+			return u
+		}
+		u = p.Elem().Underlying()
+	}
+}
+
+// leaf return the underlying type reached through the pointers, slices and arrays of typ
+func leaf(typ types.Type) types.Type {
+	u := deref(typ)
+	for {
+		switch e := u.(type) {
+		case *types.Slice:
+			u = deref(e.Elem())
+		case *types.Array:
+			u = deref(e.Elem())
+		default:
+			return u
+		}
+	}
+}
+
+func isInterface(typ types.Type) bool {
+	_, ok := typ.Underlying().(*types.Interface)
+	return ok
+}
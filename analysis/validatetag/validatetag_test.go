@@ -0,0 +1,31 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatetag_test
+
+import (
+	"testing"
+
+	"github.com/go-the-way/validator/analysis/validatetag"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), validatetag.Analyzer, "a", "c")
+}
+
+func TestAnalyzerCustom(t *testing.T) {
+	if err := validatetag.Analyzer.Flags.Set("custom", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer validatetag.Analyzer.Flags.Set("custom", "false")
+	analysistest.Run(t, analysistest.TestData(), validatetag.Analyzer, "c", "d")
+}
@@ -22,7 +22,7 @@ func TestNewArrLengthFunc(t *testing.T) {
 	}
 }
 
-func TestNewArrLengthFuncPanic(t *testing.T) {
+func TestNewArrLengthFuncErr(t *testing.T) {
 	for _, tc := range []struct {
 		name string
 		str  string
@@ -31,16 +31,10 @@ func TestNewArrLengthFuncPanic(t *testing.T) {
 		{"ParseInt", "A", parseNumError("ParseInt", "A")},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			defer func() {
-				re := recover()
-				if re == nil {
-					t.Fatalf("%s failed: error expect not nil", t.Name())
-				}
-				if re == nil || !reflect.DeepEqual(tc.err, re) {
-					t.Fatalf("%s failed: error expect [%v], but got [%v]\n", t.Name(), tc.err, re)
-				}
-			}()
-			_ = ArrLengthFunc(tc.str)
+			passed, msg := ArrLengthFunc(tc.str).Valid(reflect.ValueOf(0))
+			if passed || msg != tc.err.Error() {
+				t.Fatalf("%s failed: expect [false %v], but got [%v %v]\n", t.Name(), tc.err, passed, msg)
+			}
 		})
 	}
 }
@@ -24,20 +24,21 @@ type arrMinLengthFunc struct {
 }
 
 // ArrMinLengthFunc method
-func ArrMinLengthFunc(str string) VFunc {
+func ArrMinLengthFunc(str string) VFunc { return vFuncOf(parseArrMinLengthFunc(str)) }
+
+func parseArrMinLengthFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	vStr, msg := str, ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		vStr = str[:spIdx]
-		msg = str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
 	}
-	if v, err := strconv.ParseInt(vStr, 10, 64); err != nil {
-		panic(err)
-	} else {
-		return &arrMinLengthFunc{int(v), msg}
+	v, err := strconv.ParseInt(vStr, 10, 64)
+	if err != nil {
+		return nil, err
 	}
+	return &arrMinLengthFunc{int(v), msg}, nil
 }
 
 // Valid method
@@ -46,8 +47,11 @@ func (f *arrMinLengthFunc) Valid(value reflect.Value) (bool, string) {
 	typ := value.Type()
 	switch {
 	case reflectx.IsPtr(typ):
+		if value.IsNil() {
+			break
+		}
 		return f.Valid(value.Elem())
-	case reflectx.IsArray(typ) || reflectx.IsSlice(typ):
+	case reflectx.IsArray(typ) || reflectx.IsSlice(typ) || typ.Kind() == reflect.Map:
 		passed = f.minLength <= value.Len()
 	}
 	return passed, msg
@@ -0,0 +1,174 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ruleParsers maps the name of every built-in rule to its parser
+var ruleParsers = func() map[string]func(str string) (VFunc, error) {
+	parsers := make(map[string]func(str string) (VFunc, error))
+	for _, r := range new(Item).rules() {
+		parsers[r.name] = r.parse
+	}
+	return parsers
+}()
+
+// builtRules holds the rules built in code of every struct type, by field name
+var builtRules = struct {
+	sync.RWMutex
+	types map[reflect.Type]map[string][]Rule
+}{types: make(map[reflect.Type]map[string][]Rule)}
+
+// Rule is a rule built in code, e.g. with the rules package
+type Rule struct {
+	name  string
+	param string
+	vf    VFunc
+	msg   string
+	str   string
+	err   error
+}
+
+// NewRule return the built-in rule named name with the value of its tag syntax,
+// e.g. NewRule("min", "18") is the rule of min(18). The error of an unknown rule or a malformed value
+// is reported by Validator.Err like a malformed tag
+func NewRule(name, value string) Rule {
+	if alias, ok := ruleAliases[name]; ok {
+		name = alias
+	}
+	r := Rule{name: name, str: value}
+	parse, ok := ruleParsers[name]
+	if !ok {
+		r.err = fmt.Errorf("unknown rule %s", name)
+		return r
+	}
+	if r.vf, r.err = parse(value); r.vf == nil && r.err == nil {
+		r.err = errors.New("empty value")
+	}
+	r.param, _, _ = splitMsg(value)
+	return r
+}
+
+// RuleOf return a rule named name validated by vf, param is reported by FieldError.Param
+func RuleOf(name, param string, vf VFunc) Rule {
+	r := Rule{name: name, param: param, vf: vf, str: param}
+	if vf == nil {
+		r.err = errors.New("nil VFunc")
+	}
+	return r
+}
+
+// Name return the name of the rule, aliases are resolved
+func (r Rule) Name() string { return r.name }
+
+// Param return the param of the rule, e.g. 18 for NewRule("min", "18,too young")
+func (r Rule) Param() string { return r.param }
+
+// Err return the error of an unknown rule or a malformed value, or nil
+func (r Rule) Err() error { return r.err }
+
+// Msg return a copy of the rule failing with msg
+func (r Rule) Msg(msg string) Rule { r.msg = msg; return r }
+
+// Builder adds rules built in code to the fields of a struct type, see For
+type Builder struct {
+	typ reflect.Type
+}
+
+// For return the Builder of the struct type of structPtr. Rules are registered for the type itself,
+// so they apply wherever it is validated, nested in other structs included. Types of other packages can be given rules
+// the same way. Rules should be added before validating, typically in init, as every compiled schema is reset.
+func For(structPtr interface{}) *Builder { return &Builder{reflect.TypeOf(structPtr).Elem()} }
+
+// Field adds rules to the field named name, they are run after the rules of its tag.
+// A rule replaces the rule of the same name given by the tag or by a previous call.
+// Unknown fields and rules that cannot validate the field are reported by Validator.Err
+func (b *Builder) Field(name string, rules ...Rule) *Builder {
+	if len(rules) == 0 {
+		return b
+	}
+	builtRules.Lock()
+	fields := builtRules.types[b.typ]
+	if fields == nil {
+		fields = make(map[string][]Rule)
+		builtRules.types[b.typ] = fields
+	}
+	fields[name] = mergeRule(fields[name], rules...)
+	builtRules.Unlock()
+	resetSchemas()
+	return b
+}
+
+// mergeRule return the rules with rules added, replacing those of the same name
+func mergeRule(built []Rule, rules ...Rule) []Rule {
+	merged := append([]Rule(nil), built...)
+	for _, r := range rules {
+		replaced := false
+		for i := range merged {
+			if merged[i].name == r.name {
+				merged[i], replaced = r, true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+// fieldRules return the rules built in code of the fields of typ
+func fieldRules(typ reflect.Type) map[string][]Rule {
+	builtRules.RLock()
+	defer builtRules.RUnlock()
+	return builtRules.types[typ]
+}
+
+// resetSchemas drops every compiled schema, they are compiled again with the rules built in code
+func resetSchemas() {
+	schemaCache.Range(func(key, _ interface{}) bool {
+		schemaCache.Delete(key)
+		return true
+	})
+}
+
+// compileBuilt adds the rules built in code to the compiled rules of a field, replacing the rules of the same name
+func (c *schemaCompiler) compileBuilt(es *elemSchema, built []Rule, structType reflect.Type, field reflect.StructField) TagErrors {
+	var errs TagErrors
+	for _, b := range built {
+		err := b.err
+		if err == nil {
+			err = es.item.check(b.name, b.vf, c.root, structType, field)
+		}
+		if err != nil {
+			errs = append(errs, &TagError{structType, field.Name, b.name, b.str, err})
+			continue
+		}
+		r := &rule{name: b.name, param: b.param, vf: b.vf, msg: b.msg}
+		replaced := false
+		for i := range es.rules {
+			if es.rules[i].name == r.name {
+				es.rules[i], replaced = r, true
+				break
+			}
+		}
+		if !replaced {
+			es.rules = append(es.rules, r)
+		}
+	}
+	return errs
+}
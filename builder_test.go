@@ -0,0 +1,116 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testBuiltUser struct {
+	Name  string `validate:"minlength(2,short) maxlength(5)"`
+	Email string
+	Age   int
+	Skip  string `validate:"-"`
+}
+
+type testBuiltOrder struct {
+	User testBuiltUser
+}
+
+func TestBuilder(t *testing.T) {
+	For(&testBuiltUser{}).
+		Field("Name", NewRule("maxlength", "3")).
+		Field("Email", NewRule("required", "T"), NewRule("regex", "@")).
+		Field("Age", NewRule("min", "18").Msg("too young")).
+		Field("Skip", NewRule("required", "T")).
+		Field("Age")
+	for _, tc := range []struct {
+		name   string
+		user   testBuiltUser
+		errors map[string][]string
+	}{
+		{"Valid", testBuiltUser{"abc", "a@b", 18, "s"}, map[string][]string{}},
+		{"Invalid", testBuiltUser{"a", "", 1, ""}, map[string][]string{
+			"Name": {"minlength"}, "Email": {"required"}, "Age": {"min"}, "Skip": {"required"}}},
+		{"Override", testBuiltUser{"abcd", "b", 18, "s"}, map[string][]string{"Name": {"maxlength"}, "Email": {"regex"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, r := range []*Result{New(&tc.user).Validate(), New(&testBuiltOrder{tc.user}).Validate()} {
+				errs := make(map[string][]string)
+				for _, item := range r.Items {
+					for _, fe := range item.Errors {
+						path := fe.Path
+						if len(path) > 5 && path[:5] == "User." {
+							path = path[5:]
+						}
+						errs[path] = append(errs[path], fe.Rule)
+					}
+				}
+				if !reflect.DeepEqual(errs, tc.errors) {
+					t.Fatalf("%s failed: expect %v, but got %v\n", t.Name(), tc.errors, errs)
+				}
+			}
+		})
+	}
+	r := New(&testBuiltUser{"abc", "a@b", 1, "s"}).Validate()
+	if msg := r.Messages(); msg != "too young" {
+		t.Fatalf("test failed: msg expect [too young], but got [%v]\n", msg)
+	}
+	r = New(&testBuiltUser{"abcd", "a@b", 18, "s"}).Validate()
+	if fe := r.Items[0].Errors[0]; fe.Param != "3" {
+		t.Fatalf("test failed: param expect [3], but got [%v]\n", fe.Param)
+	}
+}
+
+func TestBuilderErr(t *testing.T) {
+	type model struct {
+		Name string
+		Age  int
+	}
+	For(&model{}).
+		Field("Unknown", NewRule("required", "T")).
+		Field("Name", NewRule("min", "A"), NewRule("unknown", "1"), NewRule("enum", ""), RuleOf("x", "", nil)).
+		Field("Age", NewRule("enum", "a|b"), NewRule("eqfield", "Name"))
+	errs, ok := New(&model{}).Err().(TagErrors)
+	if !ok {
+		t.Fatalf("test failed: expect TagErrors, but got [%v]\n", New(&model{}).Err())
+	}
+	rules := make([]string, len(errs))
+	for i, err := range errs {
+		rules[i] = err.Field + "." + err.Rule
+	}
+	expect := []string{"Unknown.required", "Name.min", "Name.unknown", "Name.enum", "Name.x", "Age.enum", "Age.eqfield"}
+	if !reflect.DeepEqual(rules, expect) {
+		t.Fatalf("test failed: expect %v, but got %v\n", expect, rules)
+	}
+	if !errors.Is(errs, ErrTag) {
+		t.Fatal("test failed: expect ErrTag")
+	}
+}
+
+func TestNewRule(t *testing.T) {
+	r := NewRule("arr_length", "2,fail")
+	if r.name != "size" || r.param != "2" || r.err != nil || !reflect.DeepEqual(r.vf, ArrLengthFunc("2,fail")) {
+		t.Fatalf("test failed: unexpected rule %+v\n", r)
+	}
+	if r = r.Msg("msg"); r.msg != "msg" {
+		t.Fatal("test failed: expect msg")
+	}
+	if r.Name() != "size" || r.Param() != "2" || r.Err() != nil {
+		t.Fatalf("test failed: unexpected accessors [%s] [%s] [%v]\n", r.Name(), r.Param(), r.Err())
+	}
+	if err := NewRule("min", "abc").Err(); err == nil {
+		t.Fatal("test failed: expect error")
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"strings"
+	"sync"
+)
+
+// DefaultLang is the language of the default messages when a Validator has no Lang
+const DefaultLang = "en"
+
+// DefaultCatalog is used by every Validator without its own Catalog
+var DefaultCatalog = NewCatalog()
+
+// builtinMessages are the default message templates of the rules by language, see FormatMessage for the placeholders
+var builtinMessages = map[string]map[string]string{
+	"en": {
+		"required":         "{field} is required",
+		"required_if":      "{field} is required when {conditions}",
+		"required_unless":  "{field} is required unless {conditions}",
+		"required_with":    "{field} is required when {conditions}",
+		"required_without": "{field} is required when {conditions}",
+		"min":              "{field} must be at least {min}",
+		"max":              "{field} must be at most {max}",
+		"length":           "{field} must have a length of {len}",
+		"size":             "{field} must have {len} elements",
+		"minlength":        "{field} must have a length of at least {min}",
+		"minsize":          "{field} must have at least {min} elements",
+		"maxlength":        "{field} must have a length of at most {max}",
+		"maxsize":          "{field} must have at most {max} elements",
+		"enum":             "{field} must be one of {enum}",
+		"regex":            "{field} must match {regex}",
+		"valid":            "{field} is invalid",
+		"eqfield":          "{field} must be equal to {other}",
+		"nefield":          "{field} must not be equal to {other}",
+		"gtfield":          "{field} must be greater than {other}",
+		"ltfield":          "{field} must be less than {other}",
+		"custom":           "{field} is invalid",
+		"tag":              "{field} has a malformed validate tag",
+	},
+	"zh-CN": {
+		"required":         "{field}不能为空",
+		"required_if":      "{field}在{conditions}时不能为空",
+		"required_unless":  "{field}在{conditions}以外时不能为空",
+		"required_with":    "{field}在{conditions}时不能为空",
+		"required_without": "{field}在{conditions}时不能为空",
+		"min":              "{field}不能小于{min}",
+		"max":              "{field}不能大于{max}",
+		"length":           "{field}的长度必须为{len}",
+		"size":             "{field}必须有{len}个元素",
+		"minlength":        "{field}的长度不能小于{min}",
+		"minsize":          "{field}至少需要{min}个元素",
+		"maxlength":        "{field}的长度不能大于{max}",
+		"maxsize":          "{field}最多只能有{max}个元素",
+		"enum":             "{field}必须是{enum}之一",
+		"regex":            "{field}的格式不正确",
+		"valid":            "{field}无效",
+		"eqfield":          "{field}必须等于{other}",
+		"nefield":          "{field}不能等于{other}",
+		"gtfield":          "{field}必须大于{other}",
+		"ltfield":          "{field}必须小于{other}",
+		"custom":           "{field}无效",
+		"tag":              "{field}的校验标签有误",
+	},
+}
+
+// Catalog holds the message templates of the rules and of the message keys by language.
+// The template of a rule is its default message, used when a tag has no message,
+// message keys are messages starting with @ in tags, e.g. msg(@user.name.required) is the key user.name.required.
+// It is safe for concurrent use, the zero value is an empty catalog ready to use
+type Catalog struct {
+	mu        sync.RWMutex
+	messages  map[string]map[string]string
+	fallbacks map[string][]string
+}
+
+// NewCatalog return a catalog holding the built-in messages, in English (en) and Simplified Chinese (zh-CN),
+// zh falls back to zh-CN
+func NewCatalog() *Catalog {
+	c := &Catalog{messages: make(map[string]map[string]string), fallbacks: make(map[string][]string)}
+	for lang, messages := range builtinMessages {
+		c.Add(lang, messages)
+	}
+	c.SetFallback("zh", "zh-CN")
+	return c
+}
+
+// Set replaces the message template of a rule or of a message key in a language, e.g. Set("en", "min", "{field} is too small"),
+// custom validators are named custom:name, custom is the template of every custom validator
+func (c *Catalog) Set(lang, key, template string) { c.Add(lang, map[string]string{key: template}) }
+
+// Add replaces the message templates of rules or of message keys in a language
+func (c *Catalog) Add(lang string, templates map[string]string) {
+	lang = normLang(lang)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages == nil {
+		c.messages = make(map[string]map[string]string)
+	}
+	if c.messages[lang] == nil {
+		c.messages[lang] = make(map[string]string)
+	}
+	for key, template := range templates {
+		c.messages[lang][key] = template
+	}
+}
+
+// SetFallback set the languages looked up, in order, when a language misses a template,
+// they replace its parent language, e.g. SetFallback("zh-TW", "zh-HK", "zh")
+func (c *Catalog) SetFallback(lang string, fallback ...string) {
+	for i := range fallback {
+		fallback[i] = normLang(fallback[i])
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fallbacks == nil {
+		c.fallbacks = make(map[string][]string)
+	}
+	c.fallbacks[normLang(lang)] = fallback
+}
+
+// Template return the message template of a rule or of a message key in a language.
+// A language without the template falls back to its fallbacks, or to its parent language,
+// e.g. zh-Hant-TW, zh-Hant, zh, then to DefaultLang
+func (c *Catalog) Template(lang, key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, l := range c.chain(normLang(lang)) {
+		if template, ok := c.messages[l][key]; ok {
+			return template, true
+		}
+		if strings.HasPrefix(key, "custom:") {
+			if template, ok := c.messages[l]["custom"]; ok {
+				return template, true
+			}
+		}
+	}
+	return "", false
+}
+
+// chain return the fallback chain of lang, ending with DefaultLang
+func (c *Catalog) chain(lang string) []string {
+	chain := []string{lang}
+	for i := 0; i < len(chain); i++ {
+		next, ok := c.fallbacks[chain[i]]
+		if !ok {
+			next = nil
+			if parent := parentLang(chain[i]); parent != "" {
+				next = []string{parent}
+			}
+		}
+		for _, l := range next {
+			if !containsLang(chain, l) {
+				chain = append(chain, l)
+			}
+		}
+	}
+	if !containsLang(chain, DefaultLang) {
+		chain = append(chain, DefaultLang)
+	}
+	return chain
+}
+
+// Message return the default message of a failed rule, or the message of its key when fe.Message starts with @,
+// field is the name of the field. The message holds one translation per language of lang separated by |,
+// like the messages of tags, it is in DefaultLang when lang is empty.
+// It is used by the Validate methods written by validatorgen
+func (c *Catalog) Message(field string, fe *FieldError, lang ...string) string {
+	return c.message(lang, field, fe, nil)
+}
+
+// message return the message of fe in every language of lang, a message key missing in the catalog is its own message,
+// a rule missing in the catalog gets the custom template
+func (c *Catalog) message(lang []string, field string, fe *FieldError, extra map[string]string) string {
+	key, keyed := messageKey(fe.Message)
+	if !keyed {
+		key = fe.Rule
+	}
+	if len(lang) == 0 {
+		lang = []string{DefaultLang}
+	}
+	messages := make([]string, 0, len(lang))
+	for _, l := range lang {
+		if l == "" {
+			// like Result.Messages, empty languages are not counted
+			continue
+		}
+		template, ok := c.Template(l, key)
+		if !ok && !keyed {
+			// rules without a template, e.g. built with RuleOf, get the template of custom validators
+			template, ok = c.Template(l, "custom")
+		}
+		switch {
+		case ok:
+			// a template holds a single language
+			template = strings.Replace(template, "|", `\|`, -1)
+			messages = append(messages, formatMessage(template, field, fe, extra))
+		case keyed:
+			messages = append(messages, fe.Message)
+		default:
+			return ""
+		}
+	}
+	return strings.Join(messages, "|")
+}
+
+// messageKey return the catalog key of a message starting with @
+func messageKey(msg string) (string, bool) {
+	if len(msg) > 1 && msg[0] == '@' {
+		return msg[1:], true
+	}
+	return "", false
+}
+
+// normLang return the canonical language tag of lang with - separated subtags, e.g. zh-TW for zh_tw
+func normLang(lang string) string { return canonicalLang(strings.Replace(lang, "_", "-", -1)) }
+
+// parentLang return the language tag without its last subtag, e.g. zh for zh-TW, or an empty string
+func parentLang(lang string) string {
+	if idx := strings.LastIndexByte(lang, '-'); idx != -1 {
+		return lang[:idx]
+	}
+	return ""
+}
+
+func containsLang(langs []string, lang string) bool {
+	for _, l := range langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
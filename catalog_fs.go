@@ -0,0 +1,51 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.16
+// +build go1.16
+
+package validator
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// LoadFS loads the catalog files of fsys matching pattern, see fs.Glob and Load, e.g. LoadFS(files, "i18n/*.yaml").
+// The language of a file is its base name without extension, e.g. zh-TW for i18n/zh-TW.json,
+// or the Language header of a .po file. Nothing is loaded when a file is malformed
+func (c *Catalog) LoadFS(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	langs := make([]string, len(names))
+	files := make([]map[string]string, len(names))
+	for i, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		lang, templates, err := parseCatalog(name, data)
+		if err != nil {
+			return err
+		}
+		if lang == "" {
+			lang = strings.TrimSuffix(path.Base(name), path.Ext(name))
+		}
+		langs[i], files[i] = lang, templates
+	}
+	for i, lang := range langs {
+		c.Add(lang, files[i])
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.16
+// +build go1.16
+
+package validator
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalog_LoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"i18n/zh.json":  {Data: []byte(`{"user": {"name": {"required": "请输入姓名"}}}`)},
+		"i18n/en.yaml":  {Data: []byte("user:\n  name:\n    required: \"{field}, your name please\"\n")},
+		"i18n/fr.po":    {Data: []byte("msgid \"\"\nmsgstr \"Language: fr-CA\\n\"\n\nmsgid \"user.name.required\"\nmsgstr \"Votre nom\"\n")},
+		"i18n/README":   {Data: []byte("catalogs")},
+		"bad/en.yaml":   {Data: []byte("min: [a]")},
+		"other/de.json": {Data: []byte(`{"min": "zu klein"}`)},
+	}
+	c := NewCatalog()
+	if err := c.LoadFS(fsys, "i18n/*.*"); err != nil {
+		t.Fatalf("test failed: %v\n", err)
+	}
+	type testKey struct {
+		Name string `validate:"required(true,@user.name.required)"`
+	}
+	for _, tc := range []struct {
+		lang, expect string
+	}{
+		{"zh-TW", "请输入姓名"},
+		{"fr-CA", "Votre nom"},
+		{"fr", "Name, your name please"},
+		{"en-US", "Name, your name please"},
+	} {
+		if msg := New(&testKey{}).Catalog(c).Lang(tc.lang).Validate().Messages(); msg != tc.expect {
+			t.Fatalf("test failed: %s expect [%s], but got [%s]\n", tc.lang, tc.expect, msg)
+		}
+	}
+	if err := c.LoadFS(fsys, "bad/*"); err == nil || err.Error() != `validator: bad/en.yaml: line 1: unsupported YAML "[", quote the value` {
+		t.Fatalf("test failed: expect the YAML error, but got [%v]\n", err)
+	}
+	if err := c.LoadFS(fsys, "["); err == nil {
+		t.Fatal("test failed: expect the pattern error")
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Load adds the message templates of a catalog file to the catalog, the format is given by the extension of name:
+// .json, .yaml or .yml, or .po for gettext. Nested JSON and YAML objects give dotted keys,
+// e.g. {"user": {"name": {"required": "..."}}} is the key user.name.required.
+// A .po file names its language in its Language header when lang is empty
+func (c *Catalog) Load(lang, name string, data []byte) error {
+	fileLang, templates, err := parseCatalog(name, data)
+	if err != nil {
+		return err
+	}
+	if lang == "" {
+		lang = fileLang
+	}
+	if lang == "" {
+		return fmt.Errorf("validator: %s: unknown language", name)
+	}
+	c.Add(lang, templates)
+	return nil
+}
+
+// parseCatalog return the templates of a catalog file and the language it declares, if any
+func parseCatalog(name string, data []byte) (lang string, templates map[string]string, err error) {
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".json":
+		templates, err = parseJSONCatalog(data)
+	case ".yaml", ".yml":
+		templates, err = parseYAMLCatalog(data)
+	case ".po":
+		lang, templates, err = parsePOCatalog(data)
+	default:
+		err = fmt.Errorf("unknown catalog format %q", ext)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("validator: %s: %v", name, err)
+	}
+	return lang, templates, nil
+}
+
+// parseJSONCatalog parses an object of templates and of nested objects
+func parseJSONCatalog(data []byte) (map[string]string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	templates := make(map[string]string)
+	return templates, flattenJSON(templates, "", object)
+}
+
+func flattenJSON(templates map[string]string, prefix string, object map[string]interface{}) error {
+	for key, value := range object {
+		switch value := value.(type) {
+		case string:
+			templates[prefix+key] = value
+		case map[string]interface{}:
+			if err := flattenJSON(templates, prefix+key+".", value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s%s: expect a string or an object, but got %T", prefix, key, value)
+		}
+	}
+	return nil
+}
+
+// parseYAMLCatalog parses the block mappings of YAML, with plain, single-quoted and double-quoted scalars,
+// and comments. Sequences, flow collections, block scalars, anchors and tags are not supported.
+// Entries are indented deeper than their parent key and as much as their siblings
+func parseYAMLCatalog(data []byte) (map[string]string, error) {
+	templates := make(map[string]string)
+	// levels are the open mappings, from the outermost one, with the indentation of their entries
+	type level struct {
+		indent int
+		prefix string
+	}
+	var levels []level
+	// parent is the key of the previous entry when it has no value, its entries may follow
+	parent := ""
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \r")
+		content := strings.TrimLeft(line, " ")
+		if content == "" || content[0] == '#' || line == "---" {
+			continue
+		}
+		lineErr := func(format string, args ...interface{}) error {
+			return fmt.Errorf("line %d: %s", n+1, fmt.Sprintf(format, args...))
+		}
+		if content[0] == '\t' {
+			return nil, lineErr("tabs are not allowed in indentation")
+		}
+		indent := len(line) - len(content)
+		key, rest, err := yamlKey(content)
+		if err != nil {
+			return nil, lineErr("%v", err)
+		}
+		switch {
+		case len(levels) == 0:
+			levels = append(levels, level{indent, ""})
+		case parent != "" && indent > levels[len(levels)-1].indent:
+			levels = append(levels, level{indent, parent + "."})
+		case indent > levels[len(levels)-1].indent:
+			return nil, lineErr("unexpected indentation under a value")
+		default:
+			for len(levels) > 1 && levels[len(levels)-1].indent > indent {
+				levels = levels[:len(levels)-1]
+			}
+			if levels[len(levels)-1].indent != indent {
+				return nil, lineErr("indentation matches no parent key")
+			}
+		}
+		prefix := levels[len(levels)-1].prefix
+		value, err := yamlScalar(rest)
+		if err != nil {
+			return nil, lineErr("%v", err)
+		}
+		if rest = strings.TrimSpace(rest); rest == "" || rest[0] == '#' {
+			parent = prefix + key
+			continue
+		}
+		parent = ""
+		templates[prefix+key] = value
+	}
+	return templates, nil
+}
+
+// yamlKey splits a mapping entry into its key and the rest of the line after the colon
+func yamlKey(content string) (key, rest string, err error) {
+	switch content[0] {
+	case '-', '[', '{', '|', '>', '&', '*', '!':
+		return "", "", fmt.Errorf("unsupported YAML %q", content[:1])
+	case '\'', '"':
+		end := yamlQuoteEnd(content)
+		if end == -1 {
+			return "", "", errUnterminated
+		}
+		if key, err = yamlScalar(content[:end+1]); err != nil {
+			return "", "", err
+		}
+		if content = content[end+1:]; !strings.HasPrefix(content, ":") {
+			return "", "", errors.New("expect a colon after the key")
+		}
+		return key, content[1:], nil
+	}
+	idx := strings.Index(content, ": ")
+	if idx == -1 {
+		if !strings.HasSuffix(content, ":") {
+			return "", "", errors.New("expect key: value")
+		}
+		idx = len(content) - 1
+	}
+	return strings.TrimSpace(content[:idx]), content[idx+1:], nil
+}
+
+// yamlScalar return the value of a scalar, quoted or plain, without its comment
+func yamlScalar(str string) (string, error) {
+	str = strings.TrimLeft(str, " ")
+	if str == "" || str[0] == '#' {
+		return "", nil
+	}
+	switch str[0] {
+	case '|', '>', '[', '{', '&', '*', '!':
+		return "", fmt.Errorf("unsupported YAML %q, quote the value", str[:1])
+	case '\'', '"':
+		end := yamlQuoteEnd(str)
+		if end == -1 {
+			return "", errUnterminated
+		}
+		if rest := strings.TrimSpace(str[end+1:]); rest != "" && rest[0] != '#' {
+			return "", errAfterQuote
+		}
+		if str[0] == '"' {
+			return strconv.Unquote(str[:end+1])
+		}
+		return strings.Replace(str[1:end], "''", "'", -1), nil
+	}
+	if idx := strings.Index(str, " #"); idx != -1 {
+		str = str[:idx]
+	}
+	return strings.TrimSpace(str), nil
+}
+
+// yamlQuoteEnd return the index of the quote closing the scalar starting str, or -1
+func yamlQuoteEnd(str string) int {
+	quote := str[0]
+	for i := 1; i < len(str); i++ {
+		switch {
+		case quote == '"' && str[i] == '\\':
+			i++
+		case quote == '\'' && str[i] == '\'' && i+1 < len(str) && str[i+1] == '\'':
+			i++
+		case str[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// parsePOCatalog parses the msgid and msgstr of a gettext .po file, msgid is the key and msgstr the template.
+// Untranslated, fuzzy and msgctxt entries are skipped, plural entries give their first form,
+// the language is read from the Language header of the empty msgid
+func parsePOCatalog(data []byte) (string, map[string]string, error) {
+	var lang string
+	templates := make(map[string]string)
+	var (
+		id, str, ctxt string
+		fuzzy, seen   bool
+		field         *string
+	)
+	flush := func() {
+		switch {
+		case !seen:
+		case id == "":
+			for _, header := range strings.Split(str, "\n") {
+				if strings.HasPrefix(header, "Language:") {
+					lang = strings.TrimSpace(strings.TrimPrefix(header, "Language:"))
+				}
+			}
+		case str != "" && !fuzzy && ctxt == "":
+			templates[id] = str
+		}
+		id, str, ctxt, fuzzy, seen, field = "", "", "", false, false, nil
+	}
+	var ignored string
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		lineErr := func(err error) error { return fmt.Errorf("line %d: %v", n+1, err) }
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if seen {
+				flush()
+			}
+			fuzzy = fuzzy || strings.Contains(line, "fuzzy")
+			continue
+		case line[0] == '#':
+			continue
+		case line[0] == '"':
+			if field == nil {
+				return "", nil, lineErr(errors.New("string without keyword"))
+			}
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return "", nil, lineErr(err)
+			}
+			*field += s
+			continue
+		}
+		idx := strings.IndexByte(line, ' ')
+		if idx == -1 {
+			return "", nil, lineErr(fmt.Errorf("expect a keyword and a string, but got %q", line))
+		}
+		keyword := line[:idx]
+		switch {
+		case keyword == "msgctxt":
+			if seen {
+				flush()
+			}
+			field = &ctxt
+		case keyword == "msgid":
+			if seen && (id != "" || str != "") {
+				flush()
+			}
+			seen, field = true, &id
+		case keyword == "msgstr" || keyword == "msgstr[0]":
+			field = &str
+		case keyword == "msgid_plural" || strings.HasPrefix(keyword, "msgstr["):
+			ignored, field = "", &ignored
+		default:
+			return "", nil, lineErr(fmt.Errorf("unknown keyword %q", keyword))
+		}
+		s, err := strconv.Unquote(strings.TrimSpace(line[idx+1:]))
+		if err != nil {
+			return "", nil, lineErr(err)
+		}
+		*field += s
+	}
+	flush()
+	return lang, templates, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCatalog_Load(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		file   string
+		data   string
+		lang   string
+		expect map[string]string
+	}{
+		{"JSON", "zh.json", `{"min": "{field}太小", "user": {"name": {"required": "请输入姓名"}}}`, "zh",
+			map[string]string{"min": "{field}太小", "user.name.required": "请输入姓名"}},
+		{"YAML", "zh.yaml", `
+# messages
+---
+min: "{field}太小"  # quoted
+user:
+  name:
+    required: 请输入姓名
+    min: '{field} can''t be short'
+  "age.min": 年龄太小 # plain
+max: "a\tb"
+`, "zh", map[string]string{"min": "{field}太小", "user.name.required": "请输入姓名", "user.name.min": "{field} can't be short",
+			"user.age.min": "年龄太小", "max": "a\tb"}},
+		{"PO", "messages.po", `# header
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: zh_TW\n"
+
+#: user.go:12
+msgid "user.name.required"
+msgstr "請輸入"
+"姓名"
+
+#, fuzzy
+msgid "user.name.min"
+msgstr "太短"
+
+msgid "user.name.max"
+msgstr ""
+
+msgctxt "admin"
+msgid "user.age.min"
+msgstr "管理員"
+
+msgid "item"
+msgid_plural "items"
+msgstr[0] "項目"
+msgstr[1] "項目們"
+
+msgid "min"
+msgstr "{field}太小\n"
+`, "zh-TW", map[string]string{"user.name.required": "請輸入姓名", "item": "項目", "min": "{field}太小\n"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Catalog{messages: make(map[string]map[string]string)}
+			lang := tc.lang
+			if strings.HasSuffix(tc.file, ".po") {
+				// the language of the Language header
+				lang = ""
+			}
+			if err := c.Load(lang, tc.file, []byte(tc.data)); err != nil {
+				t.Fatalf("%s failed: %v\n", t.Name(), err)
+			}
+			if !reflect.DeepEqual(c.messages[tc.lang], tc.expect) {
+				t.Fatalf("%s failed: expect %v, but got %v\n", t.Name(), tc.expect, c.messages)
+			}
+		})
+	}
+}
+
+func TestCatalog_LoadErr(t *testing.T) {
+	for _, tc := range []struct {
+		name, file, data, err string
+	}{
+		{"Format", "en.txt", "", `validator: en.txt: unknown catalog format ".txt"`},
+		{"NoLang", "messages.po", `msgid "a"` + "\n" + `msgstr "b"`, "validator: messages.po: unknown language"},
+		{"JSON", "en.json", `{"min": 1}`, "validator: en.json: min: expect a string or an object, but got float64"},
+		{"JSONSyntax", "en.json", `{`, "validator: en.json: unexpected end of JSON input"},
+		{"YAMLFlow", "en.yaml", "min: {field} is small", `validator: en.yaml: line 1: unsupported YAML "{", quote the value`},
+		{"YAMLList", "en.yaml", "min:\n  - a", `validator: en.yaml: line 2: unsupported YAML "-"`},
+		{"YAMLTab", "en.yaml", "min:\n\tmax: a", "validator: en.yaml: line 2: tabs are not allowed in indentation"},
+		{"YAMLKey", "en.yaml", "min", "validator: en.yaml: line 1: expect key: value"},
+		{"YAMLUnderValue", "en.yaml", "min: a\n  max: b", "validator: en.yaml: line 2: unexpected indentation under a value"},
+		{"YAMLDedent", "en.yaml", "min:\n  zh: a\n en: b", "validator: en.yaml: line 3: indentation matches no parent key"},
+		{"YAMLQuote", "en.yaml", `min: "a`, "validator: en.yaml: line 1: unterminated quoted argument"},
+		{"YAMLAfterQuote", "en.yaml", `min: "a" b`, "validator: en.yaml: line 1: unexpected text after quoted argument"},
+		{"POKeyword", "en.po", `msgfoo "a"`, `validator: en.po: line 1: unknown keyword "msgfoo"`},
+		{"POString", "en.po", `"a"`, "validator: en.po: line 1: string without keyword"},
+		{"POQuote", "en.po", `msgid "a`, "validator: en.po: line 1: invalid syntax"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := NewCatalog().Load("", tc.file, []byte(tc.data)); err == nil || err.Error() != tc.err {
+				t.Fatalf("%s failed: expect [%s], but got [%v]\n", t.Name(), tc.err, err)
+			}
+		})
+	}
+}
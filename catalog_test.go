@@ -0,0 +1,160 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalog_Template(t *testing.T) {
+	c := NewCatalog()
+	c.Set("fr", "min", "{field} doit être au moins {min}")
+	c.Set("zh", "min", "{field}太小")
+	for _, tc := range []struct {
+		lang, rule, expect string
+	}{
+		{"en", "min", "{field} must be at least {min}"},
+		{"zh-CN", "min", "{field}不能小于{min}"},
+		{"fr", "min", "{field} doit être au moins {min}"},
+		{"fr-CA", "min", "{field} doit être au moins {min}"},
+		{"fr", "max", "{field} must be at most {max}"},
+		{"zh-TW", "min", "{field}太小"},
+		{"de", "required", "{field} is required"},
+		{"en", "custom:unique", "{field} is invalid"},
+	} {
+		if template, ok := c.Template(tc.lang, tc.rule); !ok || template != tc.expect {
+			t.Fatalf("test failed: %s %s expect [%s], but got [%s]\n", tc.lang, tc.rule, tc.expect, template)
+		}
+	}
+	if _, ok := c.Template("en", "unknown"); ok {
+		t.Fatal("test failed: expect no template")
+	}
+	if template, _ := DefaultCatalog.Template("fr", "min"); template != "{field} must be at least {min}" {
+		t.Fatalf("test failed: expect DefaultCatalog unchanged, but got [%s]\n", template)
+	}
+}
+
+func TestCatalog_Builtin(t *testing.T) {
+	for lang, messages := range builtinMessages {
+		for rule := range ruleErrs {
+			if _, ok := messages[rule]; !ok {
+				t.Fatalf("test failed: no %s message for %s\n", lang, rule)
+			}
+		}
+	}
+}
+
+func TestDefaultMessages(t *testing.T) {
+	type testDefault struct {
+		Name  string   `validate:"required(true)"`
+		Age   int      `validate:"min(18) msg(too young|太小)"`
+		Tags  []string `validate:"minsize(1)"`
+		Email string   `validate:"regex(^.+@.+$)"`
+	}
+	c := NewCatalog()
+	c.Set("zh-CN", "minsize", "{field}不能为空")
+	for _, tc := range []struct {
+		name   string
+		v      *Validator
+		lang   string
+		expect string
+	}{
+		{"Default", New(&testDefault{Email: "a"}), "", "Name is required,too young,Tags must have at least 1 elements,Email must match ^.+@.+$"},
+		{"Zh", New(&testDefault{Email: "a"}).Lang("zh-CN"), "", "Name不能为空,too young,Tags至少需要1个元素,Email的格式不正确"},
+		{"Langs", New(&testDefault{Email: "a"}).Lang("en", "zh-CN"), "zh-CN", "Name不能为空,太小,Tags至少需要1个元素,Email的格式不正确"},
+		{"Catalog", New(&testDefault{Email: "a"}).Lang("en", "zh-CN").Catalog(c), "zh-CN", "Name不能为空,太小,Tags不能为空,Email的格式不正确"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if msg := tc.v.Validate().Messages(tc.lang); msg != tc.expect {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.expect, msg)
+			}
+		})
+	}
+	if msg := New(&struct {
+		Age int `validate:"min(x)"`
+	}{}).Validate().Messages(); msg != "Age has a malformed validate tag" {
+		t.Fatalf("test failed: expect the tag message, but got [%s]\n", msg)
+	}
+	if msg := Var(1, "min(2)").Messages(); msg != "Value must be at least 2" {
+		t.Fatalf("test failed: expect the Var message, but got [%s]\n", msg)
+	}
+}
+
+func TestCatalog_Fallback(t *testing.T) {
+	c := NewCatalog()
+	c.Set("zh", "min", "{field}太小")
+	c.Set("zh_Hant", "max", "{field}太大")
+	c.SetFallback("zh-HK", "zh-Hant", "zh-CN")
+	for _, tc := range []struct {
+		lang, rule, expect string
+	}{
+		{"zh-TW", "min", "{field}太小"},
+		{"zh-Hant-TW", "max", "{field}太大"},
+		{"zh-Hant-TW", "min", "{field}太小"},
+		{"zh_TW", "required", "{field}不能为空"},
+		{"ja", "required", "{field} is required"},
+		{"zh-HK", "max", "{field}太大"},
+		{"zh-HK", "min", "{field}不能小于{min}"},
+	} {
+		if template, _ := c.Template(tc.lang, tc.rule); template != tc.expect {
+			t.Fatalf("test failed: %s %s expect [%s], but got [%s]\n", tc.lang, tc.rule, tc.expect, template)
+		}
+	}
+}
+
+func TestCatalog_RuleWithoutTemplate(t *testing.T) {
+	if msg := DefaultCatalog.Message("ISBN", &FieldError{Rule: "isbn"}, "en", "zh-CN"); msg != "ISBN is invalid|ISBN无效" {
+		t.Fatalf("test failed: expect the custom template, but got [%s]\n", msg)
+	}
+	type book struct{ ISBN string }
+	For(&book{}).Field("ISBN", RuleOf("isbn", "", &customVFun{func(reflect.Value) (bool, string) { return false, "" }}))
+	if msg := New(&book{}).Validate().Messages(); msg != "ISBN is invalid" {
+		t.Fatalf("test failed: expect [ISBN is invalid], but got [%s]\n", msg)
+	}
+}
+
+func TestCatalog_Zero(t *testing.T) {
+	var c Catalog
+	if template, ok := c.Template("en", "min"); ok || template != "" {
+		t.Fatalf("test failed: expect no template, but got [%s]\n", template)
+	}
+	c.Set("en", "min", "{field} is too small")
+	new(Catalog).SetFallback("zh-TW", "zh-CN")
+	c.SetFallback("en-GB", "en")
+	if template, _ := c.Template("en-GB", "min"); template != "{field} is too small" {
+		t.Fatalf("test failed: expect [{field} is too small], but got [%s]\n", template)
+	}
+}
+
+func TestMessageKeys(t *testing.T) {
+	type testKeys struct {
+		Name string `validate:"required(true,@user.name.required)"`
+		Age  int    `validate:"min(18) msg(@user.age)"`
+		Nick string `validate:"required(true,@user.nick.required)"`
+	}
+	c := NewCatalog()
+	c.Add("en", map[string]string{"user.name.required": "{field} is missing", "user.age": "{field} must be {min}+"})
+	c.Add("zh-CN", map[string]string{"user.name.required": "请输入{field}", "user.age": "必须年满{min}岁|"})
+	for _, lang := range [][]string{{"en", "zh-CN"}, {"zh-CN", "en"}} {
+		result := New(&testKeys{}).Catalog(c).Lang(lang...).Validate()
+		if msg := result.Messages("zh-CN"); msg != "请输入Name,必须年满18岁|,@user.nick.required" {
+			t.Fatalf("test failed: %v expect the zh-CN messages, but got [%s]\n", lang, msg)
+		}
+		if msg := result.Messages("en"); msg != "Name is missing,Age must be 18+,@user.nick.required" {
+			t.Fatalf("test failed: %v expect the en messages, but got [%s]\n", lang, msg)
+		}
+	}
+	if msg := New(&testKeys{}).Validate().Messages(); msg != "@user.name.required,@user.age,@user.nick.required" {
+		t.Fatalf("test failed: expect the keys, but got [%s]\n", msg)
+	}
+}
@@ -0,0 +1,825 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-the-way/validator"
+)
+
+// basicKinds maps the predeclared types to their kind
+var basicKinds = map[string]string{
+	"bool": "bool", "string": "string",
+	"int": "int", "int8": "int", "int16": "int", "int32": "int", "int64": "int", "rune": "int",
+	"uint": "uint", "uint8": "uint", "uint16": "uint", "uint32": "uint", "uint64": "uint", "byte": "uint",
+	"float32": "float", "float64": "float", "complex64": "complex", "complex128": "complex", "uintptr": "uintptr",
+}
+
+// fieldType is the type of a field as far as the rules are concerned
+type fieldType struct {
+	kind string     // kind of basicKinds, struct, ptr, slice, array, map or other for interfaces, chans, funcs and unsafe pointers
+	typ  types.Type // typ is the Go type, named types are of the kind of their underlying type
+	elem *fieldType // elem of ptr, slice, array and map types
+	key  *fieldType // key of map types
+}
+
+// fieldType return the type of a field, named types are resolved to their underlying type
+func (g *generator) fieldType(typ types.Type) *fieldType {
+	if t, ok := g.types[typ]; ok {
+		return t
+	}
+	t := &fieldType{kind: "other", typ: typ}
+	// recursive types like type Tree []Tree reach themselves
+	g.types[typ] = t
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		if kind, ok := basicKinds[u.Name()]; ok {
+			t.kind = kind
+		}
+	case *types.Pointer:
+		t.kind, t.elem = "ptr", g.fieldType(u.Elem())
+	case *types.Slice:
+		t.kind, t.elem = "slice", g.fieldType(u.Elem())
+	case *types.Array:
+		t.kind, t.elem = "array", g.fieldType(u.Elem())
+	case *types.Map:
+		t.kind, t.key, t.elem = "map", g.fieldType(u.Key()), g.fieldType(u.Elem())
+	case *types.Struct:
+		t.kind = "struct"
+	}
+	return t
+}
+
+// basic reports whether values of the type are copied into FieldError.Value for unexported fields
+func (t *fieldType) basic() bool {
+	switch t.kind {
+	case "bool", "string", "int", "uint", "float", "uintptr":
+		return true
+	}
+	return false
+}
+
+// comparable reports whether values of the type can be compared to their zero value with ==
+func (t *fieldType) comparable() bool { return types.Comparable(t.typ) }
+
+// String return the Go type, the types of other packages are qualified by their package name
+func (t *fieldType) String() string {
+	return types.TypeString(t.typ, func(p *types.Package) string { return p.Name() })
+}
+
+// structDecl is a struct type declared in the package
+type structDecl struct {
+	name string
+	typ  *types.Struct
+}
+
+// field is a struct field having rules or nested structs
+type field struct {
+	index    int // index of the field in the struct
+	name     string
+	exported bool
+	embedded bool
+	typ      *fieldType
+	item     *validator.Item // item is nil when the field only has nested structs
+	nested   bool            // nested reports whether the structs reached through the field have rules
+	pos      token.Position
+}
+
+// fieldRule is a rule of a field with its param and message
+type fieldRule struct {
+	name, str, param, msg string
+}
+
+// generator writes the Validate methods of a package
+type generator struct {
+	fset      *token.FileSet
+	pkg       *types.Package
+	errs      []error // errs are the type errors of the package
+	generated map[string]bool
+	types     map[types.Type]*fieldType
+	nested    map[*types.Struct]bool
+	imports   map[string]bool
+	vars      bytes.Buffer
+	body      bytes.Buffer
+	tmp       int
+}
+
+// generate return the source of the Validate methods of the named struct types of the package in dir
+func generate(dir string, names []string) ([]byte, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{fset: token.NewFileSet(), generated: make(map[string]bool), types: make(map[types.Type]*fieldType),
+		nested: make(map[*types.Struct]bool), imports: map[string]bool{"github.com/go-the-way/validator": true}}
+	files := make([]*ast.File, 0, len(pkg.GoFiles))
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	// type errors only fail the fields of the generated types, the package may call Validate methods not written yet
+	conf := types.Config{Importer: importer.ForCompiler(g.fset, "source", nil), Error: func(err error) { g.errs = append(g.errs, err) }}
+	g.pkg, _ = conf.Check(pkg.ImportPath, g.fset, files, nil)
+	decls := make([]*structDecl, 0, len(names))
+	for _, name := range names {
+		var st *types.Struct
+		if obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName); ok && !obj.IsAlias() {
+			st, _ = obj.Type().Underlying().(*types.Struct)
+		}
+		if st == nil {
+			return nil, fmt.Errorf("struct type %s not found in %s", name, pkg.Dir)
+		}
+		g.generated[name] = true
+		decls = append(decls, &structDecl{name, st})
+	}
+	for _, decl := range decls {
+		if err := g.writeType(decl); err != nil {
+			return nil, err
+		}
+	}
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by validatorgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		// the standard library first
+		if std := !strings.Contains(imports[i], "."); std != !strings.Contains(imports[j], ".") {
+			return std
+		}
+		return imports[i] < imports[j]
+	})
+	for i, path := range imports {
+		if i > 0 && strings.Contains(path, ".") && !strings.Contains(imports[i-1], ".") {
+			src.WriteString("\n")
+		}
+		fmt.Fprintf(&src, "%q\n", path)
+	}
+	src.WriteString(")\n")
+	if g.vars.Len() > 0 {
+		fmt.Fprintf(&src, "\nvar (\n%s)\n", g.vars.String())
+	}
+	src.Write(g.body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// typeName return the name of a type in the generated file, importing the packages it refers to
+func (g *generator) typeName(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = true
+		return p.Name()
+	})
+}
+
+// fields return the fields of a struct having rules or nested structs, like the schema compiled by validator.New
+func (g *generator) fields(decl *structDecl) ([]*field, error) {
+	var fields []*field
+	for i := 0; i < decl.typ.NumFields(); i++ {
+		v := decl.typ.Field(i)
+		tag := reflect.StructTag(decl.typ.Tag(i)).Get("validate")
+		if tag == "-" {
+			continue
+		}
+		pos := g.fset.Position(v.Pos())
+		item, err := validator.ParseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s.%s: %v", pos, decl.name, v.Name(), err)
+		}
+		nested := true
+		if item != nil && item.Nested != "" {
+			if nested, err = strconv.ParseBool(item.Nested); err != nil {
+				return nil, fmt.Errorf("%s: %s.%s: invalid nested(%s): %v", pos, decl.name, v.Name(), item.Nested, err)
+			}
+		}
+		nested = nested && g.hasRules(v.Type())
+		if item == nil && !nested {
+			continue
+		}
+		if strings.Contains(types.TypeString(v.Type(), nil), "invalid type") {
+			return nil, fmt.Errorf("%s: %s.%s: %s", pos, decl.name, v.Name(), g.typeError(pos))
+		}
+		typ := g.fieldType(v.Type())
+		if item != nil && typ.kind == "other" {
+			return nil, fmt.Errorf("%s: %s.%s: rules on interfaces, chans and funcs are not supported", pos, decl.name, v.Name())
+		}
+		fields = append(fields, &field{i, v.Name(), v.Exported(), v.Embedded(), typ, item, nested, pos})
+	}
+	return fields, nil
+}
+
+// typeError return the message of the type error on the line of pos
+func (g *generator) typeError(pos token.Position) string {
+	for _, err := range g.errs {
+		if terr, ok := err.(types.Error); ok {
+			if at := terr.Fset.Position(terr.Pos); at.Filename == pos.Filename && at.Line == pos.Line {
+				return terr.Msg
+			}
+		}
+	}
+	return "invalid type"
+}
+
+// hasRules reports whether the struct reached through the pointers and collections of typ has rules like the nested
+// schemas of validator.New, that is fields with rules or reaching such structs. A struct being checked has rules
+func (g *generator) hasRules(typ types.Type) bool {
+	st := structElem(typ)
+	if st == nil {
+		return false
+	}
+	if has, ok := g.nested[st]; ok {
+		return has
+	}
+	g.nested[st] = true
+	has := false
+	for i := 0; i < st.NumFields() && !has; i++ {
+		tag := reflect.StructTag(st.Tag(i)).Get("validate")
+		if tag == "-" {
+			continue
+		}
+		item, err := validator.ParseTag(tag)
+		nested := true
+		if item != nil && item.Nested != "" {
+			nested, _ = strconv.ParseBool(item.Nested)
+		}
+		has = item != nil || err != nil || (nested && g.hasRules(st.Field(i).Type()))
+	}
+	g.nested[st] = has
+	return has
+}
+
+// structElem return the struct reached through the pointers and collections of typ, or nil
+func structElem(typ types.Type) *types.Struct {
+	for {
+		switch u := typ.Underlying().(type) {
+		case *types.Pointer:
+			typ = u.Elem()
+		case *types.Slice:
+			typ = u.Elem()
+		case *types.Array:
+			typ = u.Elem()
+		case *types.Map:
+			typ = u.Elem()
+		case *types.Struct:
+			return u
+		default:
+			return nil
+		}
+	}
+}
+
+// writeType writes the Validate method of a struct type and the validateItems method used by the types nesting it
+func (g *generator) writeType(decl *structDecl) error {
+	fields, err := g.fields(decl)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.body, "\n// Validate validates x like validator.New(x).Validate(), without reflection\n")
+	fmt.Fprintf(&g.body, "func (x *%s) Validate() *validator.Result {\nreturn validator.NewResult(x, x.validateItems(\"\"))\n}\n", decl.name)
+	fmt.Fprintf(&g.body, "\n// validateItems return the items of x, the paths of its fields are prefixed by path\n")
+	fmt.Fprintf(&g.body, "func (x *%s) validateItems(path string) []*validator.ResultItem {\n", decl.name)
+	if len(fields) == 0 {
+		fmt.Fprintf(&g.body, "return nil\n}\n")
+		return nil
+	}
+	fmt.Fprintf(&g.body, "var items []*validator.ResultItem\ns := x\nif s == nil {\ns = new(%s)\n}\n", decl.name)
+	fieldsVar := "validate" + decl.name + "Fields"
+	items := 0
+	for _, f := range fields {
+		if f.item != nil || !f.embedded {
+			// paths of embedded structs are promoted
+			fmt.Fprintf(&g.body, "prefix := path\nif prefix != \"\" {\nprefix += \".\"\n}\n")
+			break
+		}
+	}
+	for _, f := range fields {
+		if f.item == nil {
+			continue
+		}
+		if items == 0 {
+			g.imports["reflect"] = true
+			fmt.Fprintf(&g.vars, "%s = [...]reflect.StructField{\n", fieldsVar)
+		}
+		fmt.Fprintf(&g.vars, "reflect.TypeOf(%s{}).Field(%d),\n", decl.name, f.index)
+		items++
+	}
+	if items > 0 {
+		fmt.Fprintf(&g.vars, "}\n")
+	}
+	items = 0
+	for _, f := range fields {
+		if f.item != nil {
+			if err := g.writeField(decl, f, fmt.Sprintf("&%s[%d]", fieldsVar, items)); err != nil {
+				return err
+			}
+			items++
+		}
+		if f.nested {
+			path := "prefix + " + strconv.Quote(f.name)
+			if f.embedded {
+				path = "path"
+			}
+			g.writeNested(f.typ, "s."+f.name, path)
+		}
+	}
+	fmt.Fprintf(&g.body, "return items\n}\n")
+	return nil
+}
+
+// writeNested writes the validation of the structs reached through the value x like validator.New, path is the expression
+// of its path. Structs of the generated types are validated by their validateItems method, other structs by validator.New
+func (g *generator) writeNested(t *fieldType, x, path string) {
+	switch t.kind {
+	case "ptr":
+		fmt.Fprintf(&g.body, "if %s != nil {\n", x)
+		if t.elem.kind == "struct" {
+			g.writeStruct(t.elem, x, x, path)
+		} else {
+			g.writeNested(t.elem, "(*"+x+")", path)
+		}
+		g.body.WriteString("}\n")
+	case "struct":
+		g.writeStruct(t, x, "&"+x, path)
+	case "slice", "array":
+		g.tmp++
+		i := "i" + strconv.Itoa(g.tmp)
+		g.imports["strconv"] = true
+		fmt.Fprintf(&g.body, "for %s := range %s {\n", i, x)
+		g.writeNested(t.elem, x+"["+i+"]", concat(concat(path, "[")+" + strconv.Itoa("+i+")", "]"))
+		g.body.WriteString("}\n")
+	case "map":
+		// like validator, the values are walked in the order of their formatted keys
+		g.tmp++
+		n := strconv.Itoa(g.tmp)
+		keys, k, v := "keys"+n, "k"+n, "v"+n
+		g.imports["fmt"], g.imports["sort"] = true, true
+		fmt.Fprintf(&g.body, "%s := make([]%s, 0, len(%s))\nfor %s := range %s {\n%s = append(%s, %s)\n}\n",
+			keys, g.typeName(t.key.typ), x, k, x, keys, keys, k)
+		fmt.Fprintf(&g.body, "sort.Slice(%s, func(i, j int) bool { return fmt.Sprint(%s[i]) < fmt.Sprint(%s[j]) })\n", keys, keys, keys)
+		fmt.Fprintf(&g.body, "for _, %s := range %s {\n%s := %s[%s]\n", k, keys, v, x, k)
+		key := "fmt.Sprint(" + k + ")"
+		if t.key.kind == "string" {
+			g.imports["strconv"] = true
+			key = "strconv.Quote(" + stringValue(t.key, k) + ")"
+		}
+		g.writeNested(t.elem, v, concat(concat(path, "[")+" + "+key, "]"))
+		g.body.WriteString("}\n")
+	}
+}
+
+// writeStruct writes the validation of the struct x pointed by ptr
+func (g *generator) writeStruct(t *fieldType, x, ptr, path string) {
+	if named, ok := t.typ.(*types.Named); ok && named.Obj().Pkg() == g.pkg && g.generated[named.Obj().Name()] {
+		fmt.Fprintf(&g.body, "items = append(items, %s.validateItems(%s)...)\n", x, path)
+		return
+	}
+	fmt.Fprintf(&g.body, "items = append(items, validator.New(%s).Path(%s).Validate().Items...)\n", ptr, path)
+}
+
+// concat return the string expression path followed by the literal s
+func concat(path, s string) string {
+	if strings.HasSuffix(path, `"`) {
+		return path[:len(path)-1] + s + `"`
+	}
+	return path + ` + "` + s + `"`
+}
+
+// stringValue return the string of the value x of a string kind, converting named string types
+func stringValue(t *fieldType, x string) string {
+	if types.Identical(t.typ, types.Typ[types.String]) {
+		return x
+	}
+	return "string(" + x + ")"
+}
+
+// writeField writes the ResultItem of a field, the rules are run in the order of validator.Item
+func (g *generator) writeField(decl *structDecl, f *field, fieldRef string) error {
+	rules, err := g.rules(decl, f)
+	if err != nil {
+		return err
+	}
+	x := "s." + f.name
+	var presence, checks bytes.Buffer
+	guard := false
+	for _, r := range rules {
+		on, _ := strconv.ParseBool(r.param)
+		switch r.name {
+		case "required":
+			if on {
+				guard = true
+				fmt.Fprintf(&presence, "if empty {\n%s}\n", g.fail(f, r))
+			}
+		case "omitempty":
+			guard = guard || on
+		default:
+			if code := g.check(f, r, x); code != "" {
+				fmt.Fprintf(&checks, "ok = true\n%sif !ok {\n%s}\n", code, g.fail(f, r))
+			}
+		}
+	}
+	fmt.Fprintf(&g.body, "{\nitem := &validator.ResultItem{Field: %s, Path: prefix + %q, Message: %q}\n", fieldRef, f.name, f.item.Msg)
+	if presence.Len() > 0 || (guard && checks.Len() > 0) {
+		empty, err := g.empty(f.typ, x, f.exported)
+		if err != nil {
+			return fmt.Errorf("%s: %s.%s: %v", f.pos, decl.name, f.name, err)
+		}
+		fmt.Fprintf(&g.body, "empty := %s\n", empty)
+	}
+	g.body.Write(presence.Bytes())
+	if checks.Len() > 0 {
+		g.body.WriteString("var ok bool\n")
+		if guard {
+			fmt.Fprintf(&g.body, "if !empty {\n%s}\n", checks.String())
+		} else {
+			g.body.Write(checks.Bytes())
+		}
+	}
+	g.body.WriteString("item.Passed = len(item.Errors) == 0\nitems = append(items, item)\n}\n")
+	return nil
+}
+
+// fail return the statements reporting a failed rule, with the messages of validator.Item
+func (g *generator) fail(f *field, r fieldRule) string {
+	msg, set := strings.TrimSpace(r.msg), ""
+	own := msg != ""
+	if !own {
+		msg = f.item.Msg
+	}
+	value := "s." + f.name
+	if !f.exported && !f.typ.basic() {
+		// like validator, values of unexported fields are only copied when they are basic
+		value = "nil"
+	}
+	if msg == "" {
+		// default messages are taken from validator.DefaultCatalog at validation time
+		return fmt.Sprintf("fe := &validator.FieldError{Path: item.Path, Rule: %q, Param: %q, Value: %s}\nfe.Message = validator.DefaultCatalog.Message(%q, fe)\nitem.Errors = append(item.Errors, fe)\nitem.Message = fe.Message\n",
+			r.name, r.param, value, f.name)
+	}
+	keyed := strings.HasPrefix(msg, "@") && len(msg) > 1
+	if !keyed && !strings.Contains(msg, "{") {
+		if own {
+			set = fmt.Sprintf("item.Message = %q\n", msg)
+		}
+		return fmt.Sprintf("item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: %q, Param: %q, Value: %s, Message: %q})\n%s",
+			r.name, r.param, value, msg, set)
+	}
+	// templates and message keys are resolved at validation time, the item message is only overridden by rule messages
+	set = "if item.Message == " + strconv.Quote(f.item.Msg) + " {\nitem.Message = fe.Message\n}\n"
+	if own {
+		set = "item.Message = fe.Message\n"
+	}
+	format := fmt.Sprintf("validator.FormatMessage(%q, %q, fe)", msg, f.name)
+	if keyed {
+		format = fmt.Sprintf("validator.DefaultCatalog.Message(%q, fe)", f.name)
+	}
+	return fmt.Sprintf("fe := &validator.FieldError{Path: item.Path, Rule: %q, Param: %q, Value: %s, Message: %q}\nfe.Message = %s\nitem.Errors = append(item.Errors, fe)\n%s",
+		r.name, r.param, value, msg, format, set)
+}
+
+// unsupported are the rules validatorgen cannot inline
+var unsupported = []string{
+	"custom", "eqfield", "nefield", "gtfield", "ltfield",
+	"required_if", "required_unless", "required_with", "required_without", "keys", "values", "dive",
+}
+
+// rules return the rules of a field in the order of validator.Item, checking them like validator.New
+func (g *generator) rules(decl *structDecl, f *field) ([]fieldRule, error) {
+	item := f.item
+	values := map[string]string{
+		"custom": item.Custom, "eqfield": item.EqField, "nefield": item.NeField, "gtfield": item.GtField, "ltfield": item.LtField,
+		"required_if": item.RequiredIf, "required_unless": item.RequiredUnless,
+		"required_with": item.RequiredWith, "required_without": item.RequiredWithout,
+		"keys": item.Keys, "values": item.Values, "dive": item.Dive,
+	}
+	for _, name := range unsupported {
+		if values[name] != "" {
+			return nil, fmt.Errorf("%s: %s.%s: %s(%s) is not supported by validatorgen", f.pos, decl.name, f.name, name, values[name])
+		}
+	}
+	var rules []fieldRule
+	for _, r := range []struct{ name, str string }{
+		{"required", item.Required}, {"omitempty", item.OmitEmpty},
+		{"min", item.Min}, {"max", item.Max}, {"length", item.Length}, {"size", item.ArrLength},
+		{"minlength", item.MinLength}, {"minsize", item.ArrMinLength},
+		{"maxlength", item.MaxLength}, {"maxsize", item.ArrMaxLength},
+		{"enum", item.Enum}, {"regex", item.Regex}, {"valid", item.Valid},
+	} {
+		if r.str == "" {
+			continue
+		}
+		fr := fieldRule{name: r.name, str: r.str}
+		var err error
+		if fr.param, fr.msg, err = validator.SplitValue(r.str); r.name == "omitempty" {
+			fr.param, fr.msg, err = r.str, "", nil
+		}
+		if err == nil {
+			err = checkRule(f.typ, fr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s.%s: invalid %s(%s): %v", f.pos, decl.name, f.name, r.name, r.str, err)
+		}
+		rules = append(rules, fr)
+	}
+	return rules, nil
+}
+
+// checkRule reports a rule that validator.New reports as a malformed tag
+func checkRule(typ *fieldType, r fieldRule) error {
+	var err error
+	switch r.name {
+	case "required", "omitempty", "valid":
+		_, err = strconv.ParseBool(r.param)
+	case "min", "max":
+		_, err = strconv.ParseFloat(r.param, 64)
+	case "length", "size", "minlength", "minsize", "maxlength", "maxsize":
+		_, err = strconv.ParseInt(r.param, 10, 64)
+	case "regex":
+		_, err = regexp.Compile(r.param)
+	case "enum":
+		leaf := typ
+		for leaf.kind == "ptr" || leaf.kind == "slice" || leaf.kind == "array" {
+			leaf = leaf.elem
+		}
+		for _, option := range strings.Split(r.param, "|") {
+			switch leaf.kind {
+			case "int":
+				_, err = strconv.ParseInt(option, 10, 64)
+			case "uint":
+				_, err = strconv.ParseUint(option, 10, 64)
+			}
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if validator.IsElemRule(r.name) {
+		for typ.kind == "ptr" {
+			typ = typ.elem
+		}
+		if typ.kind == "map" {
+			return fmt.Errorf("not supported on maps, use keys(...) or values(...)")
+		}
+	}
+	return nil
+}
+
+// checker return the condition passing a value of the type, or whether every element of the value is checked instead
+type checker func(t *fieldType, x string) (cond string, each bool)
+
+// check return the statements clearing ok when the value x fails the rule, or an empty string when it always passes
+func (g *generator) check(f *field, r fieldRule, x string) string {
+	switch r.name {
+	case "min":
+		return g.walk(f.typ, x, g.numberChecker(r.param, "<="))
+	case "max":
+		return g.walk(f.typ, x, g.numberChecker(r.param, ">="))
+	case "length":
+		return g.walk(f.typ, x, lengthChecker(r.param, "==", true))
+	case "minlength":
+		return g.walk(f.typ, x, lengthChecker(r.param, "<=", true))
+	case "maxlength":
+		return g.walk(f.typ, x, lengthChecker(r.param, ">=", false))
+	case "size":
+		return g.walk(f.typ, x, sizeChecker(r.param, "=="))
+	case "minsize":
+		return g.walk(f.typ, x, sizeChecker(r.param, "<="))
+	case "maxsize":
+		return g.walk(f.typ, x, sizeChecker(r.param, ">="))
+	case "enum":
+		return g.walk(f.typ, x, enumChecker(strings.Split(r.param, "|")))
+	case "regex":
+		return g.walk(f.typ, x, g.regexChecker(r.param))
+	case "valid":
+		if v, _ := strconv.ParseBool(r.param); v {
+			return g.valid(f.typ, x)
+		}
+	}
+	return ""
+}
+
+// walk return the statements clearing ok when the value x fails the checker,
+// nil pointers pass and the elements of slices and arrays are checked until one fails
+func (g *generator) walk(t *fieldType, x string, c checker) string {
+	if t.kind == "ptr" {
+		if body := g.walk(t.elem, "*"+x, c); body != "" {
+			return fmt.Sprintf("if %s != nil {\n%s}\n", x, body)
+		}
+		return ""
+	}
+	cond, each := c(t, x)
+	if each {
+		g.tmp++
+		e := "e" + strconv.Itoa(g.tmp)
+		if body := g.walk(t.elem, e, c); body != "" {
+			return fmt.Sprintf("for _, %s := range %s {\n%sif !ok {\nbreak\n}\n}\n", e, x, body)
+		}
+		return ""
+	}
+	if cond != "" {
+		return fmt.Sprintf("if !(%s) {\nok = false\n}\n", cond)
+	}
+	return ""
+}
+
+// valid return the statements clearing ok when the value x is a nil pointer, or an element of x,
+// pointers to pointers are checked at every level
+func (g *generator) valid(t *fieldType, x string) string {
+	switch t.kind {
+	case "slice", "array":
+		g.tmp++
+		e := "e" + strconv.Itoa(g.tmp)
+		if body := g.valid(t.elem, e); body != "" {
+			return fmt.Sprintf("for _, %s := range %s {\n%sif !ok {\nbreak\n}\n}\n", e, x, body)
+		}
+	case "ptr":
+		if t.elem.kind == "ptr" {
+			return fmt.Sprintf("if %s == nil {\nok = false\n} else {\n%s}\n", x, g.valid(t.elem, "*"+x))
+		}
+		return fmt.Sprintf("if %s == nil {\nok = false\n}\n", x)
+	}
+	return ""
+}
+
+// numberChecker checks numbers against a bound with op, like bound <= value for min
+func (g *generator) numberChecker(param, op string) checker {
+	v, _ := strconv.ParseFloat(param, 64)
+	bound := strconv.FormatFloat(v, 'g', -1, 64)
+	switch {
+	case math.IsNaN(v):
+		bound = "math.NaN()"
+	case math.IsInf(v, 1):
+		bound = "math.Inf(1)"
+	case math.IsInf(v, -1):
+		bound = "math.Inf(-1)"
+	}
+	if strings.HasPrefix(bound, "math.") {
+		g.imports["math"] = true
+	}
+	return func(t *fieldType, x string) (string, bool) {
+		switch t.kind {
+		case "slice", "array":
+			return "", true
+		case "int", "uint", "float":
+			return fmt.Sprintf("%s %s float64(%s)", bound, op, x), false
+		}
+		return "", false
+	}
+}
+
+// lengthChecker checks the length of strings, slices and arrays of other elements than strings are counted
+// themselves when counted, like length and minlength, otherwise their elements are checked, like maxlength
+func lengthChecker(param, op string, counted bool) checker {
+	n, _ := strconv.ParseInt(param, 10, 64)
+	return func(t *fieldType, x string) (string, bool) {
+		switch t.kind {
+		case "slice", "array":
+			if t.elem.kind == "string" || !counted {
+				return "", true
+			}
+			return fmt.Sprintf("%d %s len(%s)", n, op, x), false
+		case "string":
+			return fmt.Sprintf("%d %s len(%s)", n, op, x), false
+		}
+		return "", false
+	}
+}
+
+// sizeChecker checks the length of slices, arrays and maps
+func sizeChecker(param, op string) checker {
+	n, _ := strconv.ParseInt(param, 10, 64)
+	return func(t *fieldType, x string) (string, bool) {
+		switch t.kind {
+		case "slice", "array", "map":
+			return fmt.Sprintf("%d %s len(%s)", n, op, x), false
+		}
+		return "", false
+	}
+}
+
+// enumChecker checks integers and strings against options
+func enumChecker(options []string) checker {
+	return func(t *fieldType, x string) (string, bool) {
+		if t.kind == "slice" || t.kind == "array" {
+			return "", true
+		}
+		conds := make([]string, 0, len(options))
+		for _, option := range options {
+			switch t.kind {
+			case "int":
+				v, _ := strconv.ParseInt(option, 10, 64)
+				conds = append(conds, fmt.Sprintf("int64(%s) == %d", x, v))
+			case "uint":
+				v, _ := strconv.ParseUint(option, 10, 64)
+				conds = append(conds, fmt.Sprintf("uint64(%s) == %d", x, v))
+			case "string":
+				conds = append(conds, fmt.Sprintf("%s == %s", x, strconv.Quote(option)))
+			}
+		}
+		return strings.Join(conds, " || "), false
+	}
+}
+
+// regexChecker checks strings against a regex compiled once in a package variable
+func (g *generator) regexChecker(pattern string) checker {
+	name := ""
+	return func(t *fieldType, x string) (string, bool) {
+		switch t.kind {
+		case "slice", "array":
+			return "", true
+		case "string":
+			if name == "" {
+				g.tmp++
+				name = "validateRegex" + strconv.Itoa(g.tmp)
+				g.imports["regexp"] = true
+				fmt.Fprintf(&g.vars, "%s = regexp.MustCompile(%s)\n", name, strconv.Quote(pattern))
+			}
+			return name + ".MatchString(" + stringValue(t, x) + ")", false
+		}
+		return "", false
+	}
+}
+
+// empty return the condition of an empty value x like validator: nil pointers, empty strings, slices and maps,
+// and zero values of other types. Types implementing validator.Emptier decide themselves, like the types with an IsZero
+// method such as time.Time, but their methods are not called for unexported fields
+func (g *generator) empty(t *fieldType, x string, exported bool) (string, error) {
+	if t.kind == "other" {
+		return "", fmt.Errorf("emptiness of interfaces, chans and funcs is not supported")
+	}
+	if method := emptyMethod(t.typ); exported && method != "" {
+		if t.kind == "ptr" {
+			return fmt.Sprintf("(%s == nil || %s.%s())", x, x, method), nil
+		}
+		if strings.HasPrefix(x, "*") {
+			x = "(" + x + ")"
+		}
+		return x + "." + method + "()", nil
+	}
+	switch t.kind {
+	case "ptr":
+		elem, err := g.empty(t.elem, "*"+x, exported)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s == nil || %s)", x, elem), nil
+	case "string", "slice", "map":
+		return "len(" + x + ") == 0", nil
+	case "bool":
+		return "!" + x, nil
+	case "struct", "array":
+		if !t.comparable() {
+			return "", fmt.Errorf("emptiness of %s is not supported", t)
+		}
+		return fmt.Sprintf("%s == (%s{})", x, g.typeName(t.typ)), nil
+	}
+	return x + " == 0", nil
+}
+
+// emptyMethod return the method deciding the emptiness of the values of a type, IsEmpty of validator.Emptier
+// before IsZero, or an empty string. Methods of pointers count as the values of fields are addressable
+func emptyMethod(typ types.Type) string {
+	for _, name := range []string{"IsEmpty", "IsZero"} {
+		obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, name)
+		if fn, ok := obj.(*types.Func); ok {
+			sig := fn.Type().(*types.Signature)
+			if sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool]) {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("internal/gentest", []string{"User", "Limits", "Quick", "Empty", "Order", "Address"})
+	if err != nil {
+		t.Fatalf("%s failed: %v\n", t.Name(), err)
+	}
+	generated, err := ioutil.ReadFile("internal/gentest/gentest_validate.go")
+	if err != nil {
+		t.Fatalf("%s failed: %v\n", t.Name(), err)
+	}
+	if !bytes.Equal(src, generated) {
+		t.Fatalf("%s failed: internal/gentest/gentest_validate.go is out of date, run go generate\n", t.Name())
+	}
+}
+
+func TestGenerateErr(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		field string
+		err   string
+	}{
+		{"Undefined", "F Missing `validate:\"required(T)\"`", "T.F: undefined: Missing"},
+		{"NamedEnum", "F Status `validate:\"enum(a|b)\"`", "invalid enum(a|b)"},
+		{"Custom", "F string `validate:\"custom(name)\"`", "custom(name) is not supported"},
+		{"EqField", "F string `validate:\"eqfield(G)\"`", "eqfield(G) is not supported"},
+		{"Dive", "F []string `validate:\"dive(min(1))\"`", "dive(min(1)) is not supported"},
+		{"Min", "F int `validate:\"min(abc)\"`", "invalid min(abc)"},
+		{"Enum", "F int `validate:\"enum(a|b)\"`", "invalid enum(a|b)"},
+		{"Regex", "F string `validate:\"regex([)\"`", "invalid regex([)"},
+		{"Map", "F map[string]int `validate:\"min(1)\"`", "not supported on maps"},
+		{"Unbalanced", "F int `validate:\"min(1\"`", "unbalanced parentheses"},
+		{"Interface", "F interface{} `validate:\"required(T)\"`", "rules on interfaces"},
+		{"Nested", "F int `validate:\"nested(x)\"`", "invalid nested(x)"},
+		{"Empty", "F [1][]int `validate:\"required(T)\"`", "emptiness of [1][]int is not supported"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "validatorgen")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			src := "package p\n\ntype Address struct{ City string }\n\ntype Status int\n\ntype T struct {\n" + tc.field + "\n}\n"
+			if err := ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := generate(dir, []string{"T"}); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("%s failed: expect error containing [%s], but got [%v]\n", t.Name(), tc.err, err)
+			}
+		})
+	}
+	if _, err := generate("internal/gentest", []string{"Missing"}); err == nil || !strings.Contains(err.Error(), "struct type Missing not found") {
+		t.Fatalf("%s failed: expect a missing type error, but got [%v]\n", t.Name(), err)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gentest holds the types whose generated Validate methods are compared to validator.New
+package gentest
+
+import "time"
+
+//go:generate go run github.com/go-the-way/validator/cmd/validatorgen -type=User,Limits,Quick,Empty,Order,Address -output=gentest_validate.go
+
+// User is validated by strings, numbers, enums and regexes
+type User struct {
+	Name     string         `validate:"required(T) minlength(2,name too short) maxlength(8)"`
+	Age      int            `validate:"min(18,too young) max(60) msg(invalid age)"`
+	Email    *string        `validate:"omitempty(true) regex('^[a-z]{1,8}@[a-z]+\\.com$','bad email, use a .com address')"`
+	Role     string         `validate:"enum(admin|user)"`
+	Tags     []string       `validate:"size(2) length(3)"`
+	Scores   []uint8        `validate:"minsize(1) enum(1|2|3,bad score)"`
+	Ratio    float32        `validate:"min(0.5,{field} must be at least {min}) max(1.5)"`
+	Deadline time.Time      `validate:"required(true,deadline required)"`
+	Timeout  *time.Duration `validate:"omitempty(true) min(1000)"`
+	Ignored  interface{}    `validate:"-"`
+	Flag     bool
+}
+
+// Limits is validated by pointers, arrays, maps and unexported fields
+type Limits struct {
+	Codes  [2]*int          `validate:"valid(T,codes required) min(1)"`
+	Meta   map[string]int   `validate:"maxsize(2)"`
+	Levels [][]int          `validate:"max(9)"`
+	Ptr    **int            `validate:"valid(T)"`
+	Words  *[]*string       `validate:"required(true) maxlength(2) minsize(1)"`
+	Pair   [2]int           `validate:"required(true,@limits.pair.required)"`
+	Kinds  []int8           `validate:"enum(-1|1) msg({field} has {value}, want {enum})"`
+	Only   string           `validate:"msg(only a message)"`
+	note   string           `validate:"length(4)"`
+	since  time.Time        `validate:"omitempty(true) required(false)"`
+	parts  []string         `validate:"required(true,parts required) maxsize(1)"`
+	Other  map[string]*bool `validate:"omitempty(true) minsize(2)"`
+}
+
+// Quick is the struct of the quickstart
+type Quick struct {
+	int `validate:"min(10,fail)"`
+}
+
+// Empty has no rules
+type Empty struct {
+	Name string
+}
+
+// Status is a named string
+type Status string
+
+// Count is a named int
+type Count int
+
+// Money decides itself whether it is empty
+type Money struct {
+	Cents int64
+}
+
+// IsEmpty implements validator.Emptier
+func (m Money) IsEmpty() bool { return m.Cents <= 0 }
+
+// Address is nested in Order
+type Address struct {
+	City string `validate:"required(true)"`
+	Zip  string `validate:"omitempty(true) length(5)"`
+}
+
+// Line is nested in Order without being generated, it is validated by validator.New
+type Line struct {
+	Sku string `validate:"required(true,{path} required)"`
+	Qty Count  `validate:"min(1)"`
+}
+
+// Order is validated by named types, Emptier and nested structs
+type Order struct {
+	Address
+	Status   Status `validate:"enum(open|closed) regex('^[a-z]+$')"`
+	Count    Count  `validate:"min(1) max(9)"`
+	Total    Money  `validate:"required(true,total required)"`
+	Deposit  *Money `validate:"omitempty(true) valid(true)"`
+	Ship     *Address
+	Billing  Address `validate:"nested(false)"`
+	Previous []Address
+	Lines    []*Line
+	Stops    map[string]Address
+	Created  time.Time
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gentest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-the-way/validator"
+)
+
+type generated interface {
+	Validate() *validator.Result
+}
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func TestValidateParity(t *testing.T) {
+	timeout := 10 * time.Millisecond
+	short := time.Duration(10)
+	one := intPtr(1)
+	for _, tc := range []struct {
+		name   string
+		value  generated
+		passed bool
+	}{
+		{"UserZero", &User{}, false},
+		{"UserNil", (*User)(nil), false},
+		{"UserPass", &User{Name: "gopher", Age: 30, Email: strPtr("go@golang.com"), Role: "admin", Tags: []string{"abc", "xyz"},
+			Scores: []uint8{1, 3}, Ratio: 1, Deadline: time.Now(), Timeout: &timeout}, true},
+		{"UserFail", &User{Name: "g", Age: 61, Email: strPtr("gopher"), Role: "root", Tags: []string{"abcd"},
+			Scores: []uint8{4}, Ratio: 2, Timeout: &short}, false},
+		{"UserEmptyEmail", &User{Name: "gopher", Age: 18, Email: strPtr(""), Role: "user", Tags: []string{"abc", "abc"},
+			Scores: []uint8{2}, Ratio: 0.5, Deadline: time.Now()}, true},
+		{"LimitsZero", &Limits{}, false},
+		{"LimitsPass", &Limits{Codes: [2]*int{intPtr(1), intPtr(2)}, Meta: map[string]int{"a": 1}, Levels: [][]int{{1}, {9}},
+			Ptr: &one, Words: &[]*string{strPtr("a")}, Pair: [2]int{0, 1}, Kinds: []int8{-1, 1}, note: "abcd",
+			parts: []string{"a"}, Other: map[string]*bool{"a": nil, "b": nil}}, true},
+		{"LimitsFail", &Limits{Codes: [2]*int{intPtr(0), nil}, Meta: map[string]int{"a": 1, "b": 2, "c": 3}, Levels: [][]int{{10}},
+			Words: &[]*string{nil, nil, nil}, Kinds: []int8{0}, note: "abc", since: time.Now(),
+			parts: []string{"a", "b"}, Other: map[string]*bool{"a": nil}}, false},
+		{"LimitsEmptyWords", &Limits{Words: &[]*string{}}, false},
+		{"LimitsLongWord", &Limits{Codes: [2]*int{one, one}, Ptr: &one, Words: &[]*string{strPtr("abc")}, Pair: [2]int{1, 0}}, false},
+		{"QuickPass", &Quick{10}, true},
+		{"QuickFail", &Quick{9}, false},
+		{"Empty", &Empty{}, true},
+		{"OrderZero", &Order{}, false},
+		{"OrderNil", (*Order)(nil), false},
+		{"OrderPass", &Order{Address: Address{City: "Paris"}, Status: "open", Count: 2, Total: Money{100}, Deposit: &Money{},
+			Ship: &Address{City: "Lyon", Zip: "69000"}, Previous: []Address{{City: "Nice"}}, Lines: []*Line{{"a", 1}, nil},
+			Stops: map[string]Address{"b": {City: "Metz"}, "a": {City: "Lille"}}, Created: time.Now()}, true},
+		{"OrderFail", &Order{Status: "Open", Count: 10, Deposit: &Money{}, Ship: &Address{Zip: "1"},
+			Previous: []Address{{City: "Nice"}, {}}, Lines: []*Line{{Qty: 1}, {Sku: "b"}},
+			Stops: map[string]Address{"b": {}, "a": {City: "Lille", Zip: "1"}}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, want := tc.value.Validate(), validator.New(tc.value).Validate()
+			if got.Passed != tc.passed {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), tc.passed, got.Passed)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s failed: expect the result of validator.New, got messages [%s], want [%s]\n", t.Name(), got.Messages(), want.Messages())
+			}
+		})
+	}
+}
@@ -0,0 +1,767 @@
+// Code generated by validatorgen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/go-the-way/validator"
+)
+
+var (
+	validateUserFields = [...]reflect.StructField{
+		reflect.TypeOf(User{}).Field(0),
+		reflect.TypeOf(User{}).Field(1),
+		reflect.TypeOf(User{}).Field(2),
+		reflect.TypeOf(User{}).Field(3),
+		reflect.TypeOf(User{}).Field(4),
+		reflect.TypeOf(User{}).Field(5),
+		reflect.TypeOf(User{}).Field(6),
+		reflect.TypeOf(User{}).Field(7),
+		reflect.TypeOf(User{}).Field(8),
+	}
+	validateRegex1       = regexp.MustCompile("^[a-z]{1,8}@[a-z]+\\.com$")
+	validateLimitsFields = [...]reflect.StructField{
+		reflect.TypeOf(Limits{}).Field(0),
+		reflect.TypeOf(Limits{}).Field(1),
+		reflect.TypeOf(Limits{}).Field(2),
+		reflect.TypeOf(Limits{}).Field(3),
+		reflect.TypeOf(Limits{}).Field(4),
+		reflect.TypeOf(Limits{}).Field(5),
+		reflect.TypeOf(Limits{}).Field(6),
+		reflect.TypeOf(Limits{}).Field(7),
+		reflect.TypeOf(Limits{}).Field(8),
+		reflect.TypeOf(Limits{}).Field(9),
+		reflect.TypeOf(Limits{}).Field(10),
+		reflect.TypeOf(Limits{}).Field(11),
+	}
+	validateQuickFields = [...]reflect.StructField{
+		reflect.TypeOf(Quick{}).Field(0),
+	}
+	validateOrderFields = [...]reflect.StructField{
+		reflect.TypeOf(Order{}).Field(1),
+		reflect.TypeOf(Order{}).Field(2),
+		reflect.TypeOf(Order{}).Field(3),
+		reflect.TypeOf(Order{}).Field(4),
+		reflect.TypeOf(Order{}).Field(6),
+	}
+	validateRegex10       = regexp.MustCompile("^[a-z]+$")
+	validateAddressFields = [...]reflect.StructField{
+		reflect.TypeOf(Address{}).Field(0),
+		reflect.TypeOf(Address{}).Field(1),
+	}
+)
+
+// Validate validates x like validator.New(x).Validate(), without reflection
+func (x *User) Validate() *validator.Result {
+	return validator.NewResult(x, x.validateItems(""))
+}
+
+// validateItems return the items of x, the paths of its fields are prefixed by path
+func (x *User) validateItems(path string) []*validator.ResultItem {
+	var items []*validator.ResultItem
+	s := x
+	if s == nil {
+		s = new(User)
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[0], Path: prefix + "Name", Message: ""}
+		empty := len(s.Name) == 0
+		if empty {
+			fe := &validator.FieldError{Path: item.Path, Rule: "required", Param: "T", Value: s.Name}
+			fe.Message = validator.DefaultCatalog.Message("Name", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		var ok bool
+		if !empty {
+			ok = true
+			if !(2 <= len(s.Name)) {
+				ok = false
+			}
+			if !ok {
+				item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "minlength", Param: "2", Value: s.Name, Message: "name too short"})
+				item.Message = "name too short"
+			}
+			ok = true
+			if !(8 >= len(s.Name)) {
+				ok = false
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "maxlength", Param: "8", Value: s.Name}
+				fe.Message = validator.DefaultCatalog.Message("Name", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[1], Path: prefix + "Age", Message: "invalid age"}
+		var ok bool
+		ok = true
+		if !(18 <= float64(s.Age)) {
+			ok = false
+		}
+		if !ok {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "min", Param: "18", Value: s.Age, Message: "too young"})
+			item.Message = "too young"
+		}
+		ok = true
+		if !(60 >= float64(s.Age)) {
+			ok = false
+		}
+		if !ok {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "max", Param: "60", Value: s.Age, Message: "invalid age"})
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[2], Path: prefix + "Email", Message: ""}
+		empty := (s.Email == nil || len(*s.Email) == 0)
+		var ok bool
+		if !empty {
+			ok = true
+			if s.Email != nil {
+				if !(validateRegex1.MatchString(*s.Email)) {
+					ok = false
+				}
+			}
+			if !ok {
+				item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "regex", Param: "^[a-z]{1,8}@[a-z]+\\.com$", Value: s.Email, Message: "bad email, use a .com address"})
+				item.Message = "bad email, use a .com address"
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[3], Path: prefix + "Role", Message: ""}
+		var ok bool
+		ok = true
+		if !(s.Role == "admin" || s.Role == "user") {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "enum", Param: "admin|user", Value: s.Role}
+			fe.Message = validator.DefaultCatalog.Message("Role", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[4], Path: prefix + "Tags", Message: ""}
+		var ok bool
+		ok = true
+		for _, e2 := range s.Tags {
+			if !(3 == len(e2)) {
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "length", Param: "3", Value: s.Tags}
+			fe.Message = validator.DefaultCatalog.Message("Tags", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		ok = true
+		if !(2 == len(s.Tags)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "size", Param: "2", Value: s.Tags}
+			fe.Message = validator.DefaultCatalog.Message("Tags", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[5], Path: prefix + "Scores", Message: ""}
+		var ok bool
+		ok = true
+		if !(1 <= len(s.Scores)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "minsize", Param: "1", Value: s.Scores}
+			fe.Message = validator.DefaultCatalog.Message("Scores", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		ok = true
+		for _, e3 := range s.Scores {
+			if !(uint64(e3) == 1 || uint64(e3) == 2 || uint64(e3) == 3) {
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "enum", Param: "1|2|3", Value: s.Scores, Message: "bad score"})
+			item.Message = "bad score"
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[6], Path: prefix + "Ratio", Message: ""}
+		var ok bool
+		ok = true
+		if !(0.5 <= float64(s.Ratio)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "min", Param: "0.5", Value: s.Ratio, Message: "{field} must be at least {min}"}
+			fe.Message = validator.FormatMessage("{field} must be at least {min}", "Ratio", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		ok = true
+		if !(1.5 >= float64(s.Ratio)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "max", Param: "1.5", Value: s.Ratio}
+			fe.Message = validator.DefaultCatalog.Message("Ratio", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[7], Path: prefix + "Deadline", Message: ""}
+		empty := s.Deadline.IsZero()
+		if empty {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "required", Param: "true", Value: s.Deadline, Message: "deadline required"})
+			item.Message = "deadline required"
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateUserFields[8], Path: prefix + "Timeout", Message: ""}
+		empty := (s.Timeout == nil || *s.Timeout == 0)
+		var ok bool
+		if !empty {
+			ok = true
+			if s.Timeout != nil {
+				if !(1000 <= float64(*s.Timeout)) {
+					ok = false
+				}
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "min", Param: "1000", Value: s.Timeout}
+				fe.Message = validator.DefaultCatalog.Message("Timeout", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	return items
+}
+
+// Validate validates x like validator.New(x).Validate(), without reflection
+func (x *Limits) Validate() *validator.Result {
+	return validator.NewResult(x, x.validateItems(""))
+}
+
+// validateItems return the items of x, the paths of its fields are prefixed by path
+func (x *Limits) validateItems(path string) []*validator.ResultItem {
+	var items []*validator.ResultItem
+	s := x
+	if s == nil {
+		s = new(Limits)
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[0], Path: prefix + "Codes", Message: ""}
+		var ok bool
+		ok = true
+		for _, e4 := range s.Codes {
+			if e4 != nil {
+				if !(1 <= float64(*e4)) {
+					ok = false
+				}
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "min", Param: "1", Value: s.Codes}
+			fe.Message = validator.DefaultCatalog.Message("Codes", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		ok = true
+		for _, e5 := range s.Codes {
+			if e5 == nil {
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "valid", Param: "T", Value: s.Codes, Message: "codes required"})
+			item.Message = "codes required"
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[1], Path: prefix + "Meta", Message: ""}
+		var ok bool
+		ok = true
+		if !(2 >= len(s.Meta)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "maxsize", Param: "2", Value: s.Meta}
+			fe.Message = validator.DefaultCatalog.Message("Meta", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[2], Path: prefix + "Levels", Message: ""}
+		var ok bool
+		ok = true
+		for _, e6 := range s.Levels {
+			for _, e7 := range e6 {
+				if !(9 >= float64(e7)) {
+					ok = false
+				}
+				if !ok {
+					break
+				}
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "max", Param: "9", Value: s.Levels}
+			fe.Message = validator.DefaultCatalog.Message("Levels", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[3], Path: prefix + "Ptr", Message: ""}
+		var ok bool
+		ok = true
+		if s.Ptr == nil {
+			ok = false
+		} else {
+			if *s.Ptr == nil {
+				ok = false
+			}
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "valid", Param: "T", Value: s.Ptr}
+			fe.Message = validator.DefaultCatalog.Message("Ptr", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[4], Path: prefix + "Words", Message: ""}
+		empty := (s.Words == nil || len(*s.Words) == 0)
+		if empty {
+			fe := &validator.FieldError{Path: item.Path, Rule: "required", Param: "true", Value: s.Words}
+			fe.Message = validator.DefaultCatalog.Message("Words", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		var ok bool
+		if !empty {
+			ok = true
+			if s.Words != nil {
+				if !(1 <= len(*s.Words)) {
+					ok = false
+				}
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "minsize", Param: "1", Value: s.Words}
+				fe.Message = validator.DefaultCatalog.Message("Words", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+			ok = true
+			if s.Words != nil {
+				for _, e8 := range *s.Words {
+					if e8 != nil {
+						if !(2 >= len(*e8)) {
+							ok = false
+						}
+					}
+					if !ok {
+						break
+					}
+				}
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "maxlength", Param: "2", Value: s.Words}
+				fe.Message = validator.DefaultCatalog.Message("Words", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[5], Path: prefix + "Pair", Message: ""}
+		empty := s.Pair == ([2]int{})
+		if empty {
+			fe := &validator.FieldError{Path: item.Path, Rule: "required", Param: "true", Value: s.Pair, Message: "@limits.pair.required"}
+			fe.Message = validator.DefaultCatalog.Message("Pair", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[6], Path: prefix + "Kinds", Message: "{field} has {value}, want {enum}"}
+		var ok bool
+		ok = true
+		for _, e9 := range s.Kinds {
+			if !(int64(e9) == -1 || int64(e9) == 1) {
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "enum", Param: "-1|1", Value: s.Kinds, Message: "{field} has {value}, want {enum}"}
+			fe.Message = validator.FormatMessage("{field} has {value}, want {enum}", "Kinds", fe)
+			item.Errors = append(item.Errors, fe)
+			if item.Message == "{field} has {value}, want {enum}" {
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[7], Path: prefix + "Only", Message: "only a message"}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[8], Path: prefix + "note", Message: ""}
+		var ok bool
+		ok = true
+		if !(4 == len(s.note)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "length", Param: "4", Value: s.note}
+			fe.Message = validator.DefaultCatalog.Message("note", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[9], Path: prefix + "since", Message: ""}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[10], Path: prefix + "parts", Message: ""}
+		empty := len(s.parts) == 0
+		if empty {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "required", Param: "true", Value: nil, Message: "parts required"})
+			item.Message = "parts required"
+		}
+		var ok bool
+		if !empty {
+			ok = true
+			if !(1 >= len(s.parts)) {
+				ok = false
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "maxsize", Param: "1", Value: nil}
+				fe.Message = validator.DefaultCatalog.Message("parts", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateLimitsFields[11], Path: prefix + "Other", Message: ""}
+		empty := len(s.Other) == 0
+		var ok bool
+		if !empty {
+			ok = true
+			if !(2 <= len(s.Other)) {
+				ok = false
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "minsize", Param: "2", Value: s.Other}
+				fe.Message = validator.DefaultCatalog.Message("Other", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	return items
+}
+
+// Validate validates x like validator.New(x).Validate(), without reflection
+func (x *Quick) Validate() *validator.Result {
+	return validator.NewResult(x, x.validateItems(""))
+}
+
+// validateItems return the items of x, the paths of its fields are prefixed by path
+func (x *Quick) validateItems(path string) []*validator.ResultItem {
+	var items []*validator.ResultItem
+	s := x
+	if s == nil {
+		s = new(Quick)
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	{
+		item := &validator.ResultItem{Field: &validateQuickFields[0], Path: prefix + "int", Message: ""}
+		var ok bool
+		ok = true
+		if !(10 <= float64(s.int)) {
+			ok = false
+		}
+		if !ok {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "min", Param: "10", Value: s.int, Message: "fail"})
+			item.Message = "fail"
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	return items
+}
+
+// Validate validates x like validator.New(x).Validate(), without reflection
+func (x *Empty) Validate() *validator.Result {
+	return validator.NewResult(x, x.validateItems(""))
+}
+
+// validateItems return the items of x, the paths of its fields are prefixed by path
+func (x *Empty) validateItems(path string) []*validator.ResultItem {
+	return nil
+}
+
+// Validate validates x like validator.New(x).Validate(), without reflection
+func (x *Order) Validate() *validator.Result {
+	return validator.NewResult(x, x.validateItems(""))
+}
+
+// validateItems return the items of x, the paths of its fields are prefixed by path
+func (x *Order) validateItems(path string) []*validator.ResultItem {
+	var items []*validator.ResultItem
+	s := x
+	if s == nil {
+		s = new(Order)
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	items = append(items, s.Address.validateItems(path)...)
+	{
+		item := &validator.ResultItem{Field: &validateOrderFields[0], Path: prefix + "Status", Message: ""}
+		var ok bool
+		ok = true
+		if !(s.Status == "open" || s.Status == "closed") {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "enum", Param: "open|closed", Value: s.Status}
+			fe.Message = validator.DefaultCatalog.Message("Status", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		ok = true
+		if !(validateRegex10.MatchString(string(s.Status))) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "regex", Param: "^[a-z]+$", Value: s.Status}
+			fe.Message = validator.DefaultCatalog.Message("Status", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateOrderFields[1], Path: prefix + "Count", Message: ""}
+		var ok bool
+		ok = true
+		if !(1 <= float64(s.Count)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "min", Param: "1", Value: s.Count}
+			fe.Message = validator.DefaultCatalog.Message("Count", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		ok = true
+		if !(9 >= float64(s.Count)) {
+			ok = false
+		}
+		if !ok {
+			fe := &validator.FieldError{Path: item.Path, Rule: "max", Param: "9", Value: s.Count}
+			fe.Message = validator.DefaultCatalog.Message("Count", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateOrderFields[2], Path: prefix + "Total", Message: ""}
+		empty := s.Total.IsEmpty()
+		if empty {
+			item.Errors = append(item.Errors, &validator.FieldError{Path: item.Path, Rule: "required", Param: "true", Value: s.Total, Message: "total required"})
+			item.Message = "total required"
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateOrderFields[3], Path: prefix + "Deposit", Message: ""}
+		empty := (s.Deposit == nil || s.Deposit.IsEmpty())
+		var ok bool
+		if !empty {
+			ok = true
+			if s.Deposit == nil {
+				ok = false
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "valid", Param: "true", Value: s.Deposit}
+				fe.Message = validator.DefaultCatalog.Message("Deposit", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	if s.Ship != nil {
+		items = append(items, s.Ship.validateItems(prefix+"Ship")...)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateOrderFields[4], Path: prefix + "Billing", Message: ""}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	for i11 := range s.Previous {
+		items = append(items, s.Previous[i11].validateItems(prefix+"Previous["+strconv.Itoa(i11)+"]")...)
+	}
+	for i12 := range s.Lines {
+		if s.Lines[i12] != nil {
+			items = append(items, validator.New(s.Lines[i12]).Path(prefix+"Lines["+strconv.Itoa(i12)+"]").Validate().Items...)
+		}
+	}
+	keys13 := make([]string, 0, len(s.Stops))
+	for k13 := range s.Stops {
+		keys13 = append(keys13, k13)
+	}
+	sort.Slice(keys13, func(i, j int) bool { return fmt.Sprint(keys13[i]) < fmt.Sprint(keys13[j]) })
+	for _, k13 := range keys13 {
+		v13 := s.Stops[k13]
+		items = append(items, v13.validateItems(prefix+"Stops["+strconv.Quote(k13)+"]")...)
+	}
+	return items
+}
+
+// Validate validates x like validator.New(x).Validate(), without reflection
+func (x *Address) Validate() *validator.Result {
+	return validator.NewResult(x, x.validateItems(""))
+}
+
+// validateItems return the items of x, the paths of its fields are prefixed by path
+func (x *Address) validateItems(path string) []*validator.ResultItem {
+	var items []*validator.ResultItem
+	s := x
+	if s == nil {
+		s = new(Address)
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	{
+		item := &validator.ResultItem{Field: &validateAddressFields[0], Path: prefix + "City", Message: ""}
+		empty := len(s.City) == 0
+		if empty {
+			fe := &validator.FieldError{Path: item.Path, Rule: "required", Param: "true", Value: s.City}
+			fe.Message = validator.DefaultCatalog.Message("City", fe)
+			item.Errors = append(item.Errors, fe)
+			item.Message = fe.Message
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	{
+		item := &validator.ResultItem{Field: &validateAddressFields[1], Path: prefix + "Zip", Message: ""}
+		empty := len(s.Zip) == 0
+		var ok bool
+		if !empty {
+			ok = true
+			if !(5 == len(s.Zip)) {
+				ok = false
+			}
+			if !ok {
+				fe := &validator.FieldError{Path: item.Path, Rule: "length", Param: "5", Value: s.Zip}
+				fe.Message = validator.DefaultCatalog.Message("Zip", fe)
+				item.Errors = append(item.Errors, fe)
+				item.Message = fe.Message
+			}
+		}
+		item.Passed = len(item.Errors) == 0
+		items = append(items, item)
+	}
+	return items
+}
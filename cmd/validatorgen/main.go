@@ -0,0 +1,72 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command validatorgen writes reflection-free Validate methods for structs with validate tags.
+//
+// For every struct type T it writes a func (x *T) Validate() *validator.Result returning the same result
+// as validator.New(x).Validate(), with the rules inlined instead of walked by reflection on every call.
+// Tags are checked when generating, a malformed tag fails the generation instead of a validation.
+//
+// Usage, from a go:generate directive in the package declaring the types:
+//
+//	//go:generate go run github.com/go-the-way/validator/cmd/validatorgen -type=User,Order
+//
+// Named types are validated as their underlying type, and types implementing validator.Emptier decide their emptiness.
+// Nested structs are validated by their own generated methods when they are generated in the same run,
+// and by validator.New otherwise. Custom validators, cross-field, conditional, keys, values and dive rules, and rules
+// built in code with validator.For are not supported, validatorgen reports them and writes nothing. Tag such fields "-"
+// and validate them with validator.New, or use validator.New for the whole type.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("validatorgen: ")
+	types := flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output := flag.String("output", "", "output file name; default <type>_validate.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: validatorgen -type T[,T...] [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *types == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	names := strings.Split(*types, ",")
+	src, err := generate(dir, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := *output
+	if out == "" {
+		out = strings.ToLower(names[0]) + "_validate.go"
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(dir, out)
+	}
+	if err := ioutil.WriteFile(out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// cmpFieldFunc compares a field with another field reached by a dotted path,
+// the path is resolved from the struct declaring the field first, then from the validated struct
+type cmpFieldFunc struct {
+	path string
+	msg  string
+	ops  []int // ops are the accepted comparison results
+}
+
+// EqFieldFunc method
+func EqFieldFunc(str string) VFunc { return vFuncOf(parseEqFieldFunc(str)) }
+
+// NeFieldFunc method
+func NeFieldFunc(str string) VFunc { return vFuncOf(parseNeFieldFunc(str)) }
+
+// GtFieldFunc method
+func GtFieldFunc(str string) VFunc { return vFuncOf(parseGtFieldFunc(str)) }
+
+// LtFieldFunc method
+func LtFieldFunc(str string) VFunc { return vFuncOf(parseLtFieldFunc(str)) }
+
+func parseEqFieldFunc(str string) (VFunc, error) {
+	return parseCmpFieldFunc(str, 0)
+}
+
+func parseNeFieldFunc(str string) (VFunc, error) {
+	return parseCmpFieldFunc(str, -1, 1)
+}
+
+func parseGtFieldFunc(str string) (VFunc, error) {
+	return parseCmpFieldFunc(str, 1)
+}
+
+func parseLtFieldFunc(str string) (VFunc, error) {
+	return parseCmpFieldFunc(str, -1)
+}
+
+func parseCmpFieldFunc(str string, ops ...int) (VFunc, error) {
+	if str == "" {
+		return nil, nil
+	}
+	path, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
+	}
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("empty field path")
+	}
+	return &cmpFieldFunc{path, msg, ops}, nil
+}
+
+// Valid method, the other field cannot be resolved without a parent struct
+func (f *cmpFieldFunc) Valid(value reflect.Value) (bool, string) {
+	return f.validContext(context.Background(), &FieldInfo{Value: value})
+}
+
+func (f *cmpFieldFunc) validContext(_ context.Context, info *FieldInfo) (bool, string) {
+	if other, ok := fieldValue(info, f.path); ok {
+		if result, ok := compareValues(info.Value, other); ok {
+			for _, op := range f.ops {
+				if result == op {
+					return true, ""
+				}
+			}
+		}
+	}
+	return false, f.msg
+}
+
+func (f *cmpFieldFunc) checkField(root, structType reflect.Type, field reflect.StructField) error {
+	other, ok := typeByPath(structType, f.path)
+	if !ok {
+		other, ok = typeByPath(root, f.path)
+	}
+	if !ok {
+		return fmt.Errorf("unknown field %s", f.path)
+	}
+	if c := cmpClass(field.Type); c == "" || c != cmpClass(other) {
+		return fmt.Errorf("cannot compare %v with %v", field.Type, other)
+	}
+	return nil
+}
+
+// valueByPath return the field or map entry reached by the dotted path from the struct or map value
+func valueByPath(value reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		switch {
+		case value.Kind() == reflect.Struct:
+			value = value.FieldByName(name)
+		case value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String:
+			value = value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+		default:
+			return reflect.Value{}, false
+		}
+		if !value.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+	return value, true
+}
+
+// typeByPath return the type of the field reached by the dotted path from the struct type
+func typeByPath(typ reflect.Type, path string) (reflect.Type, bool) {
+	if typ == nil {
+		return nil, false
+	}
+	for _, name := range strings.Split(path, ".") {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return nil, false
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return nil, false
+		}
+		typ = field.Type
+	}
+	return typ, true
+}
+
+// cmpClass return the comparison class of typ, empty when it cannot be compared
+func cmpClass(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == timeType || typ.ConvertibleTo(timeType) && typ.Kind() == reflect.Struct:
+		return "time"
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Float64:
+		return "number"
+	case typ.Kind() == reflect.String:
+		return "string"
+	case typ.Kind() == reflect.Bool:
+		return "bool"
+	}
+	return ""
+}
+
+// compareValues return -1, 0 or 1 comparing a with b, ok is false when they cannot be compared, like NaN floats
+func compareValues(a, b reflect.Value) (int, bool) {
+	if a = derefValue(a); !a.IsValid() {
+		return 0, false
+	}
+	if b = derefValue(b); !b.IsValid() {
+		return 0, false
+	}
+	class := cmpClass(a.Type())
+	if class == "" || class != cmpClass(b.Type()) {
+		return 0, false
+	}
+	switch class {
+	case "time":
+		if !a.CanInterface() || !b.CanInterface() {
+			return 0, false
+		}
+		ta, tb := timeOf(a), timeOf(b)
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+		return 0, true
+	case "string":
+		return strings.Compare(a.String(), b.String()), true
+	case "bool":
+		return cmpFloat(boolFloat(a.Bool()), boolFloat(b.Bool())), true
+	}
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return cmpInt(a.Int(), b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return cmpUint(a.Uint(), b.Uint()), true
+	}
+	fa, fb := floatOf(a), floatOf(b)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		// NaN is neither equal to, less than nor greater than any number
+		return 0, false
+	}
+	return cmpFloat(fa, fb), true
+}
+
+func derefValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func timeOf(value reflect.Value) time.Time { return value.Convert(timeType).Interface().(time.Time) }
+
+func isIntKind(kind reflect.Kind) bool { return kind >= reflect.Int && kind <= reflect.Int64 }
+
+func isUintKind(kind reflect.Kind) bool { return kind >= reflect.Uint && kind <= reflect.Uintptr }
+
+func floatOf(value reflect.Value) float64 {
+	switch {
+	case isIntKind(value.Kind()):
+		return float64(value.Int())
+	case isUintKind(value.Kind()):
+		return float64(value.Uint())
+	}
+	return value.Float()
+}
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func cmpInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
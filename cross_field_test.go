@@ -0,0 +1,204 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testCrossAccount struct {
+	Password string
+	Limit    *int
+}
+
+type testCrossLine struct {
+	Quantity int    `validate:"ltfield(MaxQuantity)"`
+	Code     string `validate:"eqfield(Currency)"`
+}
+
+type testCrossOrder struct {
+	MaxQuantity int
+	Currency    string
+	Lines       []testCrossLine
+}
+
+func TestCrossField(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+		passed    bool
+		msg       string
+	}{
+		{"EqStringPass", &struct {
+			Password        string
+			PasswordConfirm string `validate:"eqfield(Password)"`
+		}{"a", "a"}, true, ""},
+		{"EqStringUnPass", &struct {
+			Password        string
+			PasswordConfirm string `validate:"eqfield(Password)"`
+		}{"a", "b"}, false, "PasswordConfirm must be equal to Password"},
+		{"EqStringUnPassAndMsg", &struct {
+			Password        string
+			PasswordConfirm string `validate:"eqfield(Password,fail)"`
+		}{"a", "b"}, false, "fail"},
+		{"EqStringUnPassAndGlobalMsg", &struct {
+			Password        string
+			PasswordConfirm string `validate:"eqfield(Password) msg(fail)"`
+		}{"a", "b"}, false, "fail"},
+		{"NeString", &struct {
+			Old string
+			New string `validate:"nefield(Old)"`
+		}{"a", "a"}, false, "New must not be equal to Old"},
+		{"NeStringPass", &struct {
+			Old string
+			New string `validate:"nefield(Old)"`
+		}{"a", "b"}, true, ""},
+		{"GtTime", &struct {
+			StartDate time.Time
+			EndDate   time.Time `validate:"gtfield(StartDate)"`
+		}{now, now}, false, "EndDate must be greater than StartDate"},
+		{"GtTimePass", &struct {
+			StartDate time.Time
+			EndDate   *time.Time `validate:"gtfield(StartDate)"`
+		}{now, &[]time.Time{now.Add(time.Second)}[0]}, true, ""},
+		{"LtIntFloat", &struct {
+			Max float64
+			Min int `validate:"ltfield(Max)"`
+		}{1.5, 2}, false, "Min must be less than Max"},
+		{"LtIntFloatPass", &struct {
+			Max float64
+			Min int `validate:"ltfield(Max)"`
+		}{1.5, 1}, true, ""},
+		{"NeNaN", &struct {
+			Old float64
+			New float64 `validate:"nefield(Old)"`
+		}{1, math.NaN()}, false, "New must not be equal to Old"},
+		{"LtUint", &struct {
+			Max uint
+			Min uint8 `validate:"ltfield(Max)"`
+		}{1, 1}, false, "Min must be less than Max"},
+		{"DottedPath", &struct {
+			Account  testCrossAccount
+			Password string `validate:"eqfield(Account.Password)"`
+		}{testCrossAccount{Password: "a"}, "a"}, true, ""},
+		{"DottedPathPtr", &struct {
+			Account *testCrossAccount
+			Limit   int `validate:"ltfield(Account.Limit)"`
+		}{&testCrossAccount{Limit: intPtr(10)}, 1}, true, ""},
+		{"DottedPathNil", &struct {
+			Account *testCrossAccount
+			Limit   int `validate:"ltfield(Account.Limit)"`
+		}{&testCrossAccount{}, 1}, false, "Limit must be less than Account.Limit"},
+		{"DottedPathNilParent", &struct {
+			Account *testCrossAccount
+			Limit   int `validate:"ltfield(Account.Limit)"`
+		}{}, false, "Limit must be less than Account.Limit"},
+		{"ParentStruct", &testCrossOrder{10, "USD", []testCrossLine{{1, "USD"}, {10, "USD"}, {1, "EUR"}}},
+			false, "Quantity must be less than MaxQuantity,Code must be equal to Currency"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New(tc.structPtr)
+			if err := v.Err(); err != nil {
+				t.Fatalf("%s failed: expect nil err, but got [%v]\n", t.Name(), err)
+			}
+			r := v.Validate()
+			if tc.passed != r.Passed {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), tc.passed, r.Passed)
+			}
+			if tc.msg != r.Messages() {
+				t.Fatalf("%s failed: msg expect [%v], but got [%v]\n", t.Name(), tc.msg, r.Messages())
+			}
+		})
+	}
+}
+
+func TestCrossFieldErr(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+	}{
+		{"Unknown", &struct {
+			A string `validate:"eqfield(B)"`
+		}{}},
+		{"UnknownPath", &struct {
+			A string
+			B string `validate:"eqfield(A.B)"`
+		}{}},
+		{"Incomparable", &struct {
+			A string
+			B int `validate:"gtfield(A)"`
+		}{}},
+		{"Slice", &struct {
+			A []int
+			B []int `validate:"eqfield(A)"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := New(tc.structPtr).Err()
+			if !errors.Is(err, ErrTag) {
+				t.Fatalf("%s failed: expect err, but got nil\n", t.Name())
+			}
+		})
+	}
+}
+
+func TestCrossFieldValid(t *testing.T) {
+	for _, s := range []testCase{
+		{"NoParent", EqFieldFunc("A"), reflect.ValueOf(1), false, ""},
+		{"NoParentAndMsg", EqFieldFunc("A,fail"), reflect.ValueOf(1), false, "fail"},
+	} {
+		t.Run(s.name, s.test)
+	}
+	if _, err := parseEqFieldFunc(" ,fail"); err == nil {
+		t.Fatal("test failed: expect empty field path err")
+	}
+	for _, vf := range []VFunc{EqFieldFunc(""), NeFieldFunc(""), GtFieldFunc(""), LtFieldFunc("")} {
+		if vf != nil {
+			t.Fatal("test failed: expect nil")
+		}
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		a, b   interface{}
+		result int
+		ok     bool
+	}{
+		{"Int", 1, int8(2), -1, true},
+		{"Uint", uint(2), uint8(2), 0, true},
+		{"IntUint", 3, uint(2), 1, true},
+		{"Float", 1.5, 1, 1, true},
+		{"String", "b", "a", 1, true},
+		{"Bool", false, true, -1, true},
+		{"Time", time.Unix(1, 0), time.Unix(2, 0), -1, true},
+		{"Ptr", intPtr(1), 1, 0, true},
+		{"NilPtr", (*int)(nil), 1, 0, false},
+		{"NaN", math.NaN(), 1.5, 0, false},
+		{"IntNaN", 1, math.NaN(), 0, false},
+		{"Mismatch", "1", 1, 0, false},
+		{"Slice", []int{}, []int{}, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok := compareValues(reflect.ValueOf(tc.a), reflect.ValueOf(tc.b))
+			if result != tc.result || ok != tc.ok {
+				t.Fatalf("%s failed: expect [%d %v], but got [%d %v]\n", t.Name(), tc.result, tc.ok, result, ok)
+			}
+		})
+	}
+}
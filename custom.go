@@ -11,9 +11,19 @@
 
 package validator
 
-import "reflect"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
 
-var customVFMap = make(map[string]VFunc, 0)
+// ErrRegistered is returned when a custom validator name is already registered
+var ErrRegistered = errors.New("validator: custom validator already registered")
+
+// DefaultRegistry is used by Custom and by every Validator without its own Registry
+var DefaultRegistry = NewRegistry()
 
 type customVFun struct {
 	delegate func(value reflect.Value) (bool, string)
@@ -23,6 +33,96 @@ func (c *customVFun) Valid(value reflect.Value) (bool, string) {
 	return c.delegate(value)
 }
 
+// FieldInfo describes the field under validation
+type FieldInfo struct {
+	Root   reflect.Value       // Root is the validated struct, invalid when validated alone
+	Parent reflect.Value       // Parent is the struct declaring the field, invalid when validated alone
+	Field  reflect.StructField // Field is the field metadata
+	Value  reflect.Value       // Value is the field value
+	Path   string              // Path of the field, e.g. Items[3].Sku
+}
+
+// ContextFunc is a custom validator receiving the context of the validation and the field under validation
+type ContextFunc func(ctx context.Context, info *FieldInfo) (bool, string)
+
+type customContextVFun struct {
+	delegate ContextFunc
+}
+
+// Valid validates the value with a background context and no parent struct
+func (c *customContextVFun) Valid(value reflect.Value) (bool, string) {
+	return c.delegate(context.Background(), &FieldInfo{Value: value})
+}
+
+func (c *customContextVFun) validContext(ctx context.Context, info *FieldInfo) (bool, string) {
+	return c.delegate(ctx, info)
+}
+
+// Registry holds named custom validators, it is safe for concurrent use and its zero value is ready to use
+type Registry struct {
+	mu  sync.RWMutex
+	vfs map[string]VFunc
+}
+
+// NewRegistry return new *Registry
+func NewRegistry() *Registry { return &Registry{vfs: make(map[string]VFunc)} }
+
+// Register registers a custom validator, it returns ErrRegistered when the name is already registered
+func (r *Registry) Register(name string, vF func(value reflect.Value) (bool, string)) error {
+	return r.register(name, &customVFun{vF})
+}
+
+// RegisterContext registers a context-aware custom validator, it returns ErrRegistered when the name is already registered
+func (r *Registry) RegisterContext(name string, vF ContextFunc) error {
+	return r.register(name, &customContextVFun{vF})
+}
+
+func (r *Registry) register(name string, vf VFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.vfs[name]; ok {
+		return fmt.Errorf("%w: %s", ErrRegistered, name)
+	}
+	if r.vfs == nil {
+		r.vfs = make(map[string]VFunc)
+	}
+	r.vfs[name] = vf
+	return nil
+}
+
+// Unregister removes a custom validator, it reports whether the name was registered
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.vfs[name]
+	delete(r.vfs, name)
+	return ok
+}
+
+func (r *Registry) set(name string, vf VFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.vfs == nil {
+		r.vfs = make(map[string]VFunc)
+	}
+	r.vfs[name] = vf
+}
+
+func (r *Registry) lookup(name string) VFunc {
+	if name == "" {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.vfs[name]
+}
+
+// Custom registers a custom validator into DefaultRegistry, replacing any validator of the same name
 func Custom(name string, vF func(value reflect.Value) (bool, string)) {
-	customVFMap[name] = &customVFun{vF}
+	DefaultRegistry.set(name, &customVFun{vF})
+}
+
+// CustomContext registers a context-aware custom validator into DefaultRegistry, replacing any validator of the same name
+func CustomContext(name string, vF ContextFunc) {
+	DefaultRegistry.set(name, &customContextVFun{vF})
 }
@@ -12,7 +12,10 @@
 package validator
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -27,7 +30,7 @@ func TestCustom(t *testing.T) {
 	}{
 		{"pass", "pass", func(value reflect.Value) (bool, string) { return false, "" }, &struct {
 			Name string `validate:"custom(pass)"`
-		}{}, false, ""},
+		}{}, false, "Name is invalid"},
 
 		{"unpass", "unpass", func(value reflect.Value) (bool, string) { return true, "" }, &struct {
 			Name string `validate:"custom(unpass)"`
@@ -45,3 +48,169 @@ func TestCustom(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	vF := func(value reflect.Value) (bool, string) { return false, "fail" }
+	if err := r.Register("a", vF); err != nil {
+		t.Fatalf("test failed: expect nil, but got [%v]\n", err)
+	}
+	if err := r.Register("a", vF); !errors.Is(err, ErrRegistered) {
+		t.Fatalf("test failed: expect [%v], but got [%v]\n", ErrRegistered, err)
+	}
+	if vf := r.lookup("a"); vf == nil {
+		t.Fatal("test failed: expect registered")
+	}
+	if !r.Unregister("a") {
+		t.Fatal("test failed: expect unregistered")
+	}
+	if r.Unregister("a") {
+		t.Fatal("test failed: expect not registered")
+	}
+	if vf := r.lookup("a"); vf != nil {
+		t.Fatal("test failed: expect nil")
+	}
+	if err := r.Register("a", vF); err != nil {
+		t.Fatalf("test failed: expect nil, but got [%v]\n", err)
+	}
+}
+
+func TestRegistryZero(t *testing.T) {
+	var r Registry
+	vF := func(value reflect.Value) (bool, string) { return false, "fail" }
+	if vf := r.lookup("a"); vf != nil || r.Unregister("a") {
+		t.Fatal("test failed: expect an empty registry")
+	}
+	if err := new(Registry).Register("a", vF); err != nil {
+		t.Fatalf("test failed: expect nil, but got [%v]\n", err)
+	}
+	if err := r.Register("a", vF); err != nil || r.lookup("a") == nil {
+		t.Fatalf("test failed: expect registered, but got [%v]\n", err)
+	}
+}
+
+func TestValidator_Registry(t *testing.T) {
+	model := &struct {
+		Name string `validate:"custom(scoped)"`
+	}{}
+	r1, r2 := NewRegistry(), NewRegistry()
+	_ = r1.Register("scoped", func(value reflect.Value) (bool, string) { return false, "r1" })
+	_ = r2.Register("scoped", func(value reflect.Value) (bool, string) { return false, "r2" })
+	for _, tc := range []struct {
+		name     string
+		registry *Registry
+		passed   bool
+		msg      string
+	}{
+		{"Default", nil, true, ""},
+		{"R1", r1, false, "r1"},
+		{"R2", r2, false, "r2"},
+		{"Empty", NewRegistry(), true, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			vv := New(model).Registry(tc.registry).Validate()
+			if tc.passed != vv.Passed {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), tc.passed, vv.Passed)
+			}
+			if tc.msg != vv.Messages() {
+				t.Fatalf("%s failed: msg expect [%v], but got [%v]\n", t.Name(), tc.msg, vv.Messages())
+			}
+		})
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := NewRegistry()
+	model := &struct {
+		Name string `validate:"custom(concurrent)"`
+	}{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = r.Register("concurrent", func(value reflect.Value) (bool, string) { return true, "" })
+			r.Unregister("concurrent")
+		}()
+		go func() {
+			defer wg.Done()
+			New(model).Registry(r).Validate()
+		}()
+	}
+	wg.Wait()
+}
+
+type testContextKey struct{}
+
+func TestValidator_ValidateContext(t *testing.T) {
+	type user struct {
+		Tenant   string
+		Username string `validate:"custom(unique)"`
+	}
+	r := NewRegistry()
+	_ = r.RegisterContext("unique", func(ctx context.Context, info *FieldInfo) (bool, string) {
+		taken := ctx.Value(testContextKey{}).(map[string]string)
+		tenant := info.Parent.FieldByName("Tenant").String()
+		if taken[tenant] == info.Value.String() {
+			return false, info.Path + " taken in " + tenant
+		}
+		return true, ""
+	})
+	ctx := context.WithValue(context.Background(), testContextKey{}, map[string]string{"a": "john"})
+	for _, tc := range []struct {
+		name   string
+		user   *user
+		passed bool
+		msg    string
+	}{
+		{"Taken", &user{"a", "john"}, false, "Username taken in a"},
+		{"OtherTenant", &user{"b", "john"}, true, ""},
+		{"Free", &user{"a", "jane"}, true, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			vv := New(tc.user).Registry(r).ValidateContext(ctx)
+			if tc.passed != vv.Passed {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), tc.passed, vv.Passed)
+			}
+			if tc.msg != vv.Messages() {
+				t.Fatalf("%s failed: msg expect [%v], but got [%v]\n", t.Name(), tc.msg, vv.Messages())
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := NewRegistry()
+	_ = r.RegisterContext("cancel", func(ctx context.Context, info *FieldInfo) (bool, string) {
+		cancel()
+		return true, ""
+	})
+	vv := New(&struct {
+		A string `validate:"custom(cancel)"`
+		B string `validate:"minlength(1)"`
+	}{}).Registry(r).ValidateContext(ctx)
+	if vv.Passed {
+		t.Fatal("test failed: expect un-passed")
+	}
+	if len(vv.Items) != 1 {
+		t.Fatalf("test failed: expect 1 item, but got [%d]\n", len(vv.Items))
+	}
+	if err := vv.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("test failed: expect [%v], but got [%v]\n", context.Canceled, err)
+	}
+}
+
+func TestCustomContext(t *testing.T) {
+	CustomContext("customContext", func(ctx context.Context, info *FieldInfo) (bool, string) {
+		return info.Field.Name == "Name" && !info.Parent.IsValid(), "fail"
+	})
+	i := &Item{Custom: "customContext"}
+	if passed, msg := i.Validate(reflect.StructField{Name: "Name"}, reflect.ValueOf("")); !passed || msg != "" {
+		t.Fatalf("test failed: expect [true], but got [%v %s]\n", passed, msg)
+	}
+	if passed, msg := DefaultRegistry.lookup("customContext").Valid(reflect.ValueOf("")); passed || msg != "fail" {
+		t.Fatalf("test failed: expect [false fail], but got [%v %s]\n", passed, msg)
+	}
+}
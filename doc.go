@@ -29,5 +29,35 @@
 	fmt.Println(result.Passed)
 	fmt.Println(result.Messages())
  }
+
+::tag grammar::
+
+A validate tag is a list of rules, text between rules is ignored:
+
+	tag       = { rule | other } .
+	rule      = name "(" value ")" .
+	name      = ( letter | digit | "_" ) { letter | digit | "_" } .
+	value     = param [ separator message ] .
+	separator = "," | "[,]" .
+	param     = argument .
+	message   = argument .
+	argument  = quoted | { text | "(" value ")" } .
+	quoted    = "'" { char | "\'" | "\\" } "'" .
+
+The separator is the first legacy [,] outside of parentheses and quoted arguments, or the first such comma when
+there is no [,]: regex(^\d{1,3}$[,]bad code) keeps the comma of its regex. The commas of the message are kept:
+min(1,too small, really) fails with "too small, really".
+Spaces around the value are trimmed.
+
+An argument starting with a single quote is quoted: commas, parentheses and quotes lose their meaning,
+\' is a quote and \\ a backslash, other backslashes are kept so regexes are written as they are.
+Only spaces may follow a quoted argument. Inside a struct tag, backslashes and double quotes are escaped for Go:
+
+	Code string `validate:"regex('^\\d{1,3}$','1 to 3 digits, nothing else')"`
+
+The value of msg is the whole message, unquoted when quoted, the values of keys, values and dive are tags themselves.
+
+Syntax errors are returned as a *SyntaxError with the column of the error, wrapped by the TagError of the tag.
+ParseRules and SplitValue give the parser to other tools.
 */
 package validator
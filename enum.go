@@ -22,18 +22,60 @@ import (
 type enumFunc struct {
 	options string
 	msg     string
+	ints    map[int64]struct{}
+	intErr  error
+	uints   map[uint64]struct{}
+	uintErr error
+	strings map[string]struct{}
 }
 
 // EnumFunc method
-func EnumFunc(str string) VFunc {
+func EnumFunc(str string) VFunc { return vFuncOf(parseEnumFunc(str)) }
+
+func parseEnumFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	vStr, msg := str, ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		vStr, msg = str[:spIdx], str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
+	}
+	f := &enumFunc{options: vStr, msg: msg}
+	f.parse(strings.Split(vStr, "|"))
+	return f, nil
+}
+
+// EnumOptionsFunc method, the options are taken as they are, commas and pipes included,
+// the message is given by Rule.Msg
+func EnumOptionsFunc(options ...string) VFunc {
+	f := &enumFunc{options: strings.Join(options, "|")}
+	f.parse(options)
+	return f
+}
+
+// parse parses the options once for every supported kind,
+// values of a kind whose options cannot be parsed never pass
+func (f *enumFunc) parse(enums []string) {
+	f.ints = make(map[int64]struct{}, len(enums))
+	f.uints = make(map[uint64]struct{}, len(enums))
+	f.strings = make(map[string]struct{}, len(enums))
+	for _, enum := range enums {
+		f.strings[enum] = struct{}{}
+		if parseInt, err := strconv.ParseInt(enum, 10, 64); err != nil {
+			if f.intErr == nil {
+				f.intErr = err
+			}
+		} else {
+			f.ints[parseInt] = struct{}{}
+		}
+		if parseUint, err := strconv.ParseUint(enum, 10, 64); err != nil {
+			if f.uintErr == nil {
+				f.uintErr = err
+			}
+		} else {
+			f.uints[parseUint] = struct{}{}
+		}
 	}
-	return &enumFunc{vStr, msg}
 }
 
 // Valid method
@@ -42,6 +84,9 @@ func (f *enumFunc) Valid(value reflect.Value) (bool, string) {
 	typ := value.Type()
 	switch {
 	case reflectx.IsPtr(typ):
+		if value.IsNil() {
+			break
+		}
 		return f.Valid(value.Elem())
 	case reflectx.IsArray(typ) || reflectx.IsSlice(typ):
 		for i := 0; i < value.Len(); i++ {
@@ -51,31 +96,31 @@ func (f *enumFunc) Valid(value reflect.Value) (bool, string) {
 			}
 		}
 	case reflectx.IsInt(typ):
-		set := make(map[int64]struct{}, 0)
-		for _, enum := range strings.Split(f.options, "|") {
-			parseInt, err := strconv.ParseInt(enum, 10, 64)
-			if err != nil {
-				panic(err)
-			}
-			set[parseInt] = struct{}{}
+		if f.intErr != nil {
+			return false, msg
 		}
-		_, passed = set[value.Int()]
+		_, passed = f.ints[value.Int()]
 	case reflectx.IsUint(typ):
-		set := make(map[uint64]struct{}, 0)
-		for _, enum := range strings.Split(f.options, "|") {
-			parseUint, err := strconv.ParseUint(enum, 10, 64)
-			if err != nil {
-				panic(err)
-			}
-			set[parseUint] = struct{}{}
+		if f.uintErr != nil {
+			return false, msg
 		}
-		_, passed = set[value.Uint()]
+		_, passed = f.uints[value.Uint()]
 	case reflectx.IsString(typ):
-		set := make(map[string]struct{}, 0)
-		for _, enum := range strings.Split(f.options, "|") {
-			set[enum] = struct{}{}
-		}
-		_, passed = set[value.String()]
+		_, passed = f.strings[value.String()]
 	}
 	return passed, msg
 }
+
+// check reports an option that cannot be parsed for the kind of typ
+func (f *enumFunc) check(typ reflect.Type) error {
+	for reflectx.IsPtr(typ) || reflectx.IsArray(typ) || reflectx.IsSlice(typ) {
+		typ = typ.Elem()
+	}
+	switch {
+	case reflectx.IsInt(typ):
+		return f.intErr
+	case reflectx.IsUint(typ):
+		return f.uintErr
+	}
+	return nil
+}
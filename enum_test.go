@@ -22,28 +22,22 @@ func TestNewEnumFunc(t *testing.T) {
 	}
 }
 
-func TestEnumPanic(t *testing.T) {
+func TestEnumErr(t *testing.T) {
 	for _, tc := range []struct {
 		name  string
 		str   string
 		value reflect.Value
-		err   error
+		msg   string
 	}{
-		{"ParseUint", "A", reflect.ValueOf(uint(0)), parseNumError("ParseUint", "A")},
-		{"ParseInt", "A", reflect.ValueOf(0), parseNumError("ParseInt", "A")},
+		{"ParseUint", "A", reflect.ValueOf(uint(0)), ""},
+		{"ParseInt", "A", reflect.ValueOf(0), ""},
+		{"ParseIntMsg", "A,bad", reflect.ValueOf(0), "bad"},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			defer func() {
-				re := recover()
-				if re == nil {
-					t.Fatalf("%s failed: error expect not nil", t.Name())
-				}
-				if re == nil || !reflect.DeepEqual(tc.err, re) {
-					t.Fatalf("%s failed: error expect [%v], but got [%v]\n", t.Name(), tc.err, re)
-				}
-			}()
-			vF := EnumFunc(tc.str)
-			_, _ = vF.Valid(tc.value)
+			passed, msg := EnumFunc(tc.str).Valid(tc.value)
+			if passed || msg != tc.msg {
+				t.Fatalf("%s failed: expect [false %v], but got [%v %v]\n", t.Name(), tc.msg, passed, msg)
+			}
 		})
 	}
 }
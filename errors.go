@@ -0,0 +1,149 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Rule sentinel errors, every FieldError matches the sentinel of its rule with errors.Is
+var (
+	ErrMin             = errors.New("validator: min")
+	ErrMax             = errors.New("validator: max")
+	ErrLength          = errors.New("validator: length")
+	ErrSize            = errors.New("validator: size")
+	ErrMinLength       = errors.New("validator: minlength")
+	ErrMinSize         = errors.New("validator: minsize")
+	ErrMaxLength       = errors.New("validator: maxlength")
+	ErrMaxSize         = errors.New("validator: maxsize")
+	ErrEnum            = errors.New("validator: enum")
+	ErrRegex           = errors.New("validator: regex")
+	ErrValid           = errors.New("validator: valid")
+	ErrEqField         = errors.New("validator: eqfield")
+	ErrNeField         = errors.New("validator: nefield")
+	ErrGtField         = errors.New("validator: gtfield")
+	ErrLtField         = errors.New("validator: ltfield")
+	ErrRequiredIf      = errors.New("validator: required_if")
+	ErrRequiredUnless  = errors.New("validator: required_unless")
+	ErrRequiredWith    = errors.New("validator: required_with")
+	ErrRequiredWithout = errors.New("validator: required_without")
+	ErrRequired        = errors.New("validator: required")
+	ErrCustom          = errors.New("validator: custom")
+	ErrTag             = errors.New("validator: malformed tag")
+)
+
+var ruleErrs = map[string]error{
+	"min":              ErrMin,
+	"max":              ErrMax,
+	"length":           ErrLength,
+	"size":             ErrSize,
+	"minlength":        ErrMinLength,
+	"minsize":          ErrMinSize,
+	"maxlength":        ErrMaxLength,
+	"maxsize":          ErrMaxSize,
+	"enum":             ErrEnum,
+	"regex":            ErrRegex,
+	"valid":            ErrValid,
+	"eqfield":          ErrEqField,
+	"nefield":          ErrNeField,
+	"gtfield":          ErrGtField,
+	"ltfield":          ErrLtField,
+	"required_if":      ErrRequiredIf,
+	"required_unless":  ErrRequiredUnless,
+	"required_with":    ErrRequiredWith,
+	"required_without": ErrRequiredWithout,
+	"required":         ErrRequired,
+	"tag":              ErrTag,
+}
+
+// FieldError describes a failed rule of a field
+type FieldError struct {
+	Path    string      // Path of the field, e.g. Items[3].Sku
+	Rule    string      // Rule name, e.g. min, regex or custom:xyz
+	Param   string      // Param of the rule, e.g. 10 for min(10)
+	Value   interface{} // Value of the field, nil when it cannot be read
+	Message string      // Message of the rule
+}
+
+// Error method
+func (e *FieldError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("validator: %s: %s", e.Path, e.Message)
+	}
+	return fmt.Sprintf("validator: %s: failed %s(%s)", e.Path, e.Rule, e.Param)
+}
+
+// Is reports whether target is the sentinel error of the rule
+func (e *FieldError) Is(target error) bool {
+	if target == ErrCustom {
+		return strings.HasPrefix(e.Rule, "custom:")
+	}
+	return target != nil && ruleErrs[e.Rule] == target
+}
+
+// ValidationErrors holds every failed rule of a validation
+type ValidationErrors []*FieldError
+
+// Error method
+func (es ValidationErrors) Error() string {
+	messages := make([]string, len(es))
+	for i, e := range es {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether any FieldError matches target
+func (es ValidationErrors) Is(target error) bool {
+	for _, e := range es {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As sets target to the first FieldError when target is a **FieldError
+func (es ValidationErrors) As(target interface{}) bool {
+	if fe, ok := target.(**FieldError); ok && len(es) > 0 {
+		*fe = es[0]
+		return true
+	}
+	return false
+}
+
+// valueInterface return the value as interface{}, values of unexported fields are copied when they are basic
+func valueInterface(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	if value.CanInterface() {
+		return value.Interface()
+	}
+	typ := value.Type()
+	switch value.Kind() {
+	case reflect.Bool:
+		return reflect.ValueOf(value.Bool()).Convert(typ).Interface()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(value.Int()).Convert(typ).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.ValueOf(value.Uint()).Convert(typ).Interface()
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(value.Float()).Convert(typ).Interface()
+	case reflect.String:
+		return reflect.ValueOf(value.String()).Convert(typ).Interface()
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFieldError(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		err    *FieldError
+		msg    string
+		target error
+	}{
+		{"Min", &FieldError{"Age", "min", "10", 1, "fail"}, "validator: Age: fail", ErrMin},
+		{"Regex", &FieldError{"Items[1].Sku", "regex", "^a$", "b", ""}, "validator: Items[1].Sku: failed regex(^a$)", ErrRegex},
+		{"Size", &FieldError{"Tags", "size", "2", []string{"a"}, ""}, "validator: Tags: failed size(2)", ErrSize},
+		{"Custom", &FieldError{"Name", "custom:xyz", "", "a", "fail"}, "validator: Name: fail", ErrCustom},
+		{"Tag", &FieldError{"Name", "tag", "", "a", ""}, "validator: Name: failed tag()", ErrTag},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if msg := tc.err.Error(); msg != tc.msg {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.msg, msg)
+			}
+			if !errors.Is(tc.err, tc.target) {
+				t.Fatalf("%s failed: expect is [%v]\n", t.Name(), tc.target)
+			}
+			if errors.Is(tc.err, ErrMax) {
+				t.Fatalf("%s failed: expect is not [%v]\n", t.Name(), ErrMax)
+			}
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	var err error = ValidationErrors{
+		{"Age", "min", "10", 1, "fail"},
+		{"Name", "custom:xyz", "", "a", "fail2"},
+	}
+	if msg := err.Error(); msg != "validator: Age: fail; validator: Name: fail2" {
+		t.Fatalf("test failed: unexpected message [%s]\n", msg)
+	}
+	if !errors.Is(err, ErrMin) || !errors.Is(err, ErrCustom) || errors.Is(err, ErrMax) {
+		t.Fatal("test failed: unexpected errors.Is")
+	}
+	var ve ValidationErrors
+	if !errors.As(err, &ve) || len(ve) != 2 {
+		t.Fatalf("test failed: expect ValidationErrors, but got [%v]\n", ve)
+	}
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "Age" {
+		t.Fatalf("test failed: expect first FieldError, but got [%v]\n", fe)
+	}
+}
+
+func TestValueInterface(t *testing.T) {
+	type myInt int
+	value := reflect.ValueOf(struct {
+		i  int
+		mi myInt
+		s  string
+		f  float32
+		u  uint8
+		b  bool
+		is []int
+	}{1, 2, "a", 1.5, 3, true, []int{1}})
+	for i, expect := range []interface{}{1, myInt(2), "a", float32(1.5), uint8(3), true, nil} {
+		if v := valueInterface(value.Field(i)); !reflect.DeepEqual(v, expect) {
+			t.Fatalf("test failed: expect [%v], but got [%v]\n", expect, v)
+		}
+	}
+	if v := valueInterface(reflect.ValueOf([]int{1})); !reflect.DeepEqual(v, []int{1}) {
+		t.Fatalf("test failed: expect [[1]], but got [%v]\n", v)
+	}
+	if v := valueInterface(reflect.Value{}); v != nil {
+		t.Fatalf("test failed: expect nil, but got [%v]\n", v)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Ordered is a constraint for the types ordered by < and >
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// TypedRule is a rule validating values of type T, see Check
+type TypedRule[T any] struct {
+	name  string
+	param string
+	msg   string
+	valid func(v T) (bool, string)
+}
+
+// NewTypedRule return a rule named name validated by valid, param is reported by FieldError.Param
+func NewTypedRule[T any](name, param string, valid func(v T) (bool, string)) TypedRule[T] {
+	return TypedRule[T]{name: name, param: param, valid: valid}
+}
+
+// Msg return a copy of the rule failing with msg
+func (r TypedRule[T]) Msg(msg string) TypedRule[T] { r.msg = msg; return r }
+
+// Check validates v with typed rules, every rule is run. The value is reported like Var reports it,
+// as a field named Value, so results and errors are those of the reflection engine
+func Check[T any](v T, rules ...TypedRule[T]) *Result {
+	resultItem := &ResultItem{Field: &reflect.StructField{Name: "Value", Type: reflect.TypeOf(&v).Elem()}, Path: "Value"}
+	for _, r := range rules {
+		if passed, msg := r.valid(v); !passed {
+			if r.msg != "" {
+				msg = r.msg
+			}
+			fe := &FieldError{"Value", r.name, r.param, v, msg}
+			if _, keyed := messageKey(msg); msg == "" || keyed {
+				fe.Message = DefaultCatalog.message(nil, "Value", fe, nil)
+			} else {
+				fe.Message = formatMessage(msg, "Value", fe, nil)
+			}
+			if fe.Message != "" {
+				resultItem.Message = fe.Message
+			}
+			resultItem.Errors = append(resultItem.Errors, fe)
+		}
+	}
+	resultItem.Passed = len(resultItem.Errors) == 0
+	return newResult(v, []*ResultItem{resultItem}, resultItem.Passed, nil)
+}
+
+// Min value must be >= min
+func Min[T Ordered](min T) TypedRule[T] {
+	return NewTypedRule("min", fmt.Sprint(min), func(v T) (bool, string) { return v >= min, "" })
+}
+
+// Max value must be <= max
+func Max[T Ordered](max T) TypedRule[T] {
+	return NewTypedRule("max", fmt.Sprint(max), func(v T) (bool, string) { return v <= max, "" })
+}
+
+// Length string must have a len == length, e.g. Length[string](3)
+func Length[S ~string](length int) TypedRule[S] {
+	return NewTypedRule("length", fmt.Sprint(length), func(v S) (bool, string) { return len(v) == length, "" })
+}
+
+// MinLength string must have a len >= minLength, e.g. MinLength[string](3)
+func MinLength[S ~string](minLength int) TypedRule[S] {
+	return NewTypedRule("minlength", fmt.Sprint(minLength), func(v S) (bool, string) { return len(v) >= minLength, "" })
+}
+
+// MaxLength string must have a len <= maxLength, e.g. MaxLength[string](3)
+func MaxLength[S ~string](maxLength int) TypedRule[S] {
+	return NewTypedRule("maxlength", fmt.Sprint(maxLength), func(v S) (bool, string) { return len(v) <= maxLength, "" })
+}
+
+// Required value must be not empty, like the required rule
+func Required[T any]() TypedRule[T] {
+	return NewTypedRule("required", "true", func(v T) (bool, string) { return !isEmpty(reflect.ValueOf(&v).Elem()), "" })
+}
+
+// Enum value must be one of options
+func Enum[T comparable](options ...T) TypedRule[T] {
+	param := make([]string, len(options))
+	for i, option := range options {
+		param[i] = fmt.Sprint(option)
+	}
+	return NewTypedRule("enum", strings.Join(param, "|"), func(v T) (bool, string) {
+		for _, option := range options {
+			if v == option {
+				return true, ""
+			}
+		}
+		return false, ""
+	})
+}
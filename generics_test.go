@@ -0,0 +1,87 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testLevel int
+
+type testName string
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		result *Result
+		rules  []string
+	}{
+		{"MinMax", Check(10, Min(1), Max(100)), nil},
+		{"Min", Check(0, Min(1), Max(100)), []string{"min"}},
+		{"Max", Check(2.5, Min(0.5), Max(2.0)), []string{"max"}},
+		{"Named", Check(testLevel(3), Min[testLevel](5)), []string{"min"}},
+		{"String", Check("b", Min("a"), Max("c")), nil},
+		{"Length", Check("abc", Length[string](3), MinLength[string](4), MaxLength[string](2)), []string{"minlength", "maxlength"}},
+		{"NamedString", Check(testName("ab"), MinLength[testName](3)), []string{"minlength"}},
+		{"Required", Check("", Required[string]()), []string{"required"}},
+		{"RequiredTime", Check(time.Time{}, Required[time.Time]()), []string{"required"}},
+		{"RequiredSlice", Check([]int{}, Required[[]int]()), []string{"required"}},
+		{"RequiredPtr", Check(new(int), Required[*int]()), []string{"required"}},
+		{"Enum", Check("c", Enum("a", "b")), []string{"enum"}},
+		{"EnumPass", Check(2, Enum(1, 2)), nil},
+		{"Custom", Check(3, NewTypedRule("custom:odd", "", func(v int) (bool, string) { return v%2 == 0, "odd" })), []string{"custom:odd"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var rules []string
+			for _, fe := range tc.result.Items[0].Errors {
+				rules = append(rules, fe.Rule)
+			}
+			if !reflect.DeepEqual(rules, tc.rules) {
+				t.Fatalf("%s failed: rules expect %v, but got %v\n", t.Name(), tc.rules, rules)
+			}
+			if tc.result.Passed != (len(tc.rules) == 0) {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), len(tc.rules) == 0, tc.result.Passed)
+			}
+		})
+	}
+}
+
+func TestCheckErr(t *testing.T) {
+	r := Check(12, Min(18).Msg("too young"), Enum(1, 2))
+	if msg := r.Messages(); msg != "too young,Value must be one of 1|2" {
+		t.Fatalf("test failed: msg expect [too young,Value must be one of 1|2], but got [%v]\n", msg)
+	}
+	err := r.Err()
+	var fe *FieldError
+	if !errors.Is(err, ErrMin) || !errors.Is(err, ErrEnum) || !errors.As(err, &fe) {
+		t.Fatalf("test failed: expect ErrMin and ErrEnum, but got [%v]\n", err)
+	}
+	if fe.Path != "Value" || fe.Param != "18" || fe.Value != 12 {
+		t.Fatalf("test failed: unexpected FieldError %+v\n", fe)
+	}
+	if fe = r.Err().(ValidationErrors)[1]; fe.Param != "1|2" {
+		t.Fatalf("test failed: param expect [1|2], but got [%v]\n", fe.Param)
+	}
+	if msg := Check(12, Min(18).Msg("{value} is below {min}")).Messages(); msg != "12 is below 18" {
+		t.Fatalf("test failed: msg expect [12 is below 18], but got [%v]\n", msg)
+	}
+	// typed rules report the results of the rules of the tag syntax
+	if tr, vr := Check(12, Min(18)), Var(12, "min(18)"); tr.Items[0].Errors[0].Error() != vr.Items[0].Errors[0].Error() {
+		t.Fatalf("test failed: expect [%v], but got [%v]\n", vr.Err(), tr.Err())
+	}
+}
@@ -2,4 +2,7 @@ module github.com/go-the-way/validator
 
 go 1.13
 
-require github.com/billcoding/reflectx v1.0.0
+require (
+	github.com/billcoding/reflectx v1.0.0
+	golang.org/x/text v0.3.8
+)
@@ -11,9 +11,22 @@
 
 package validator
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 // VFunc interface
 type VFunc interface {
 	Valid(value reflect.Value) (bool, string)
 }
+
+// contextVFunc is implemented by VFuncs needing the context of the validation or the parent struct
+type contextVFunc interface {
+	validContext(ctx context.Context, info *FieldInfo) (bool, string)
+}
+
+// fieldChecker is implemented by VFuncs whose arguments depend on the struct declaring the field
+type fieldChecker interface {
+	checkField(root, structType reflect.Type, field reflect.StructField) error
+}
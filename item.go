@@ -12,58 +12,305 @@
 package validator
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"strings"
+	"sync"
 )
 
+// itemCache caches the built-in rules of every validated Item
+var itemCache sync.Map
+
 // Item struct
 type Item struct {
-	Min          string `alias:"min"`           // Min for min value
-	Max          string `alias:"max"`           // Max for max value
-	MinLength    string `alias:"minlength"`     // MinLength for min length
-	ArrMinLength string `alias:"arr_minlength"` // ArrMinLength for array min length
-	MaxLength    string `alias:"maxlength"`     // MaxLength for max length
-	ArrMaxLength string `alias:"arr_maxlength"` // ArrMaxLength for array max length
-	Length       string `alias:"length"`        // Length for length
-	ArrLength    string `alias:"arr_length"`    // ArrLength for array length
-	Enum         string `alias:"enum"`          // Enum for enum values
-	Regex        string `alias:"regex"`         // Regex for regex pattern
-	Msg          string `alias:"msg"`           // Msg for message
-	Valid        string `alias:"valid"`         // Valid for valid
-	Custom       string `alias:"custom"`        // Custom for custom validator
+	Min             string `alias:"min"`              // Min for min value
+	Max             string `alias:"max"`              // Max for max value
+	MinLength       string `alias:"minlength"`        // MinLength for min length
+	ArrMinLength    string `alias:"minsize"`          // ArrMinLength for min size, alias arr_minlength
+	MaxLength       string `alias:"maxlength"`        // MaxLength for max length
+	ArrMaxLength    string `alias:"maxsize"`          // ArrMaxLength for max size, alias arr_maxlength
+	Length          string `alias:"length"`           // Length for length
+	ArrLength       string `alias:"size"`             // ArrLength for size, alias arr_length
+	Enum            string `alias:"enum"`             // Enum for enum values
+	Regex           string `alias:"regex"`            // Regex for regex pattern
+	Msg             string `alias:"msg"`              // Msg for message
+	Valid           string `alias:"valid"`            // Valid for valid
+	Custom          string `alias:"custom"`           // Custom for custom validator
+	Nested          string `alias:"nested"`           // Nested for nested struct validation
+	EqField         string `alias:"eqfield"`          // EqField for equal to another field
+	NeField         string `alias:"nefield"`          // NeField for not equal to another field
+	GtField         string `alias:"gtfield"`          // GtField for greater than another field
+	LtField         string `alias:"ltfield"`          // LtField for less than another field
+	RequiredIf      string `alias:"required_if"`      // RequiredIf for required when other fields equal values
+	RequiredUnless  string `alias:"required_unless"`  // RequiredUnless for required unless other fields equal values
+	RequiredWith    string `alias:"required_with"`    // RequiredWith for required when other fields are present
+	RequiredWithout string `alias:"required_without"` // RequiredWithout for required when other fields are missing
+	Required        string `alias:"required"`         // Required for required
+	OmitEmpty       string `alias:"omitempty"`        // OmitEmpty for skipping the other rules of an empty value
+	Keys            string `alias:"keys"`             // Keys for the rules of every map key
+	Values          string `alias:"values"`           // Values for the rules of every map value
+	Dive            string `alias:"dive"`             // Dive for the rules of every slice or array element
+}
+
+// itemRule binds a tag value to its rule constructor
+type itemRule struct {
+	name  string
+	str   string
+	parse func(str string) (VFunc, error)
+}
+
+func (i *Item) rules() []itemRule {
+	return []itemRule{
+		{"required_if", i.RequiredIf, parseRequiredIfFunc},
+		{"required_unless", i.RequiredUnless, parseRequiredUnlessFunc},
+		{"required_with", i.RequiredWith, parseRequiredWithFunc},
+		{"required_without", i.RequiredWithout, parseRequiredWithoutFunc},
+		{"required", i.Required, parseRequiredFunc},
+		{"omitempty", i.OmitEmpty, parseOmitEmptyFunc},
+		{"min", i.Min, parseMinFunc},
+		{"max", i.Max, parseMaxFunc},
+		{"length", i.Length, parseLengthFunc},
+		{"size", i.ArrLength, parseArrLengthFunc},
+		{"minlength", i.MinLength, parseMinLengthFunc},
+		{"minsize", i.ArrMinLength, parseArrMinLengthFunc},
+		{"maxlength", i.MaxLength, parseMaxLengthFunc},
+		{"maxsize", i.ArrMaxLength, parseArrMaxLengthFunc},
+		{"enum", i.Enum, parseEnumFunc},
+		{"regex", i.Regex, parseRegexFunc},
+		{"valid", i.Valid, parseValidFunc},
+		{"eqfield", i.EqField, parseEqFieldFunc},
+		{"nefield", i.NeField, parseNeFieldFunc},
+		{"gtfield", i.GtField, parseGtFieldFunc},
+		{"ltfield", i.LtField, parseLtFieldFunc},
+	}
 }
 
 func (i *Item) vfs() []VFunc {
-	return []VFunc{
-		MinFunc(i.Min),
-		MaxFunc(i.Max),
-		LengthFunc(i.Length),
-		ArrLengthFunc(i.ArrLength),
-		MinLengthFunc(i.MinLength),
-		ArrMinLengthFunc(i.ArrMinLength),
-		MaxLengthFunc(i.MaxLength),
-		ArrMaxLengthFunc(i.ArrMaxLength),
-		EnumFunc(i.Enum),
-		RegexFunc(i.Regex),
-		ValidFunc(i.Valid),
-		customVFMap[i.Custom],
-	}
-}
-
-// Validate by fields
-func (i *Item) Validate(_ reflect.StructField, value reflect.Value) (bool, string) {
-	passed, msg := true, i.Msg
-	fs := i.vfs()
-	for _, f := range fs {
-		if f != nil {
-			if passed2, msg2 := f.Valid(value); !passed2 {
-				passed = false
-				msg2 = strings.TrimSpace(msg2)
-				if msg2 != "" {
-					msg = msg2
-				}
+	rules := i.rules()
+	fs := make([]VFunc, 0, len(rules)+1)
+	for _, r := range rules {
+		fs = append(fs, vFuncOf(r.parse(r.str)))
+	}
+	return append(fs, DefaultRegistry.lookup(i.Custom))
+}
+
+// rule is a compiled rule of an Item or a rule built in code
+type rule struct {
+	name  string
+	param string
+	vf    VFunc
+	msg   string // msg overrides the message of vf
+}
+
+// cachedRules return the built-in rules of the item, they are parsed once per Item,
+// a malformed item gets a single rule failing every value with the tag error
+func (i *Item) cachedRules() []*rule {
+	rules, ok := itemCache.Load(*i)
+	if !ok {
+		compiled, errs := i.compile(nil, nil, reflect.StructField{})
+		if len(errs) > 0 {
+			compiled = []*rule{{name: "tag", vf: &errFunc{errs[0]}}}
+		}
+		rules, _ = itemCache.LoadOrStore(*i, compiled)
+	}
+	return rules.([]*rule)
+}
+
+// elemRules are the rules validating every element of arrays and slices,
+// maps give them to keys or values, slices and arrays with dive give them to dive instead
+var elemRules = map[string]bool{
+	"min": true, "max": true, "length": true, "minlength": true, "maxlength": true, "enum": true, "regex": true,
+}
+
+// IsElemRule reports whether the rule, by name or alias, validates every element of arrays and slices.
+// Such rules are not supported on maps, nor on slices and arrays with dive
+func IsElemRule(name string) bool {
+	if alias, ok := ruleAliases[name]; ok {
+		name = alias
+	}
+	return elemRules[name]
+}
+
+var (
+	intKinds   = []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64}
+	uintKinds  = []reflect.Kind{reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64}
+	floatKinds = []reflect.Kind{reflect.Float32, reflect.Float64}
+)
+
+// ruleKinds are the kinds of the values validated by the rules, values of other kinds pass them
+var ruleKinds = map[string][]reflect.Kind{
+	"min":       concatKinds(intKinds, uintKinds, floatKinds),
+	"max":       concatKinds(intKinds, uintKinds, floatKinds),
+	"enum":      concatKinds(intKinds, uintKinds, []reflect.Kind{reflect.String}),
+	"regex":     {reflect.String},
+	"maxlength": {reflect.String},
+	"length":    {reflect.String, reflect.Slice, reflect.Array},
+	"minlength": {reflect.String, reflect.Slice, reflect.Array},
+	"size":      {reflect.Slice, reflect.Array, reflect.Map},
+	"minsize":   {reflect.Slice, reflect.Array, reflect.Map},
+	"maxsize":   {reflect.Slice, reflect.Array, reflect.Map},
+	"valid":     {reflect.Ptr, reflect.Interface},
+}
+
+// RuleKinds return the kinds of the values validated by the rule, by name or alias, values of other kinds pass it,
+// or nil when the rule validates values of every kind. Pointers of other kinds are dereferenced first,
+// elems reports whether the elements of slices and arrays of other kinds are validated instead, like valid
+// and element rules do, see IsElemRule
+func RuleKinds(name string) (kinds []reflect.Kind, elems bool) {
+	if alias, ok := ruleAliases[name]; ok {
+		name = alias
+	}
+	return ruleKinds[name], elemRules[name] || name == "valid"
+}
+
+func concatKinds(kinds ...[]reflect.Kind) []reflect.Kind {
+	var all []reflect.Kind
+	for _, k := range kinds {
+		all = append(all, k...)
+	}
+	return all
+}
+
+var (
+	// errMapRule is returned for an element rule on a map
+	errMapRule = errors.New("not supported on maps, use keys(...) or values(...)")
+	// errDiveRule is returned for an element rule on a slice or array with dive
+	errDiveRule = errors.New("not supported on slices or arrays with dive, use dive(...)")
+)
+
+// typeChecker is implemented by VFuncs whose arguments depend on the field type
+type typeChecker interface {
+	check(typ reflect.Type) error
+}
+
+// compile returns the built-in rules of the item, reporting every malformed rule instead of panicking,
+// rules depending on the field type are checked when field.Type is not nil,
+// rules depending on other fields are checked when structType is not nil
+func (i *Item) compile(root, structType reflect.Type, field reflect.StructField) ([]*rule, TagErrors) {
+	var errs TagErrors
+	itemRules := i.rules()
+	rules := make([]*rule, 0, len(itemRules))
+	for _, r := range itemRules {
+		vf, err := r.parse(r.str)
+		if err == nil && vf != nil {
+			err = i.check(r.name, vf, root, structType, field)
+		}
+		if err != nil {
+			errs = append(errs, &TagError{structType, field.Name, r.name, r.str, err})
+		} else if vf != nil {
+			param, _, _ := splitMsg(r.str)
+			rules = append(rules, &rule{name: r.name, param: param, vf: vf})
+		}
+	}
+	return rules, errs
+}
+
+// check reports a rule of the item that cannot validate the field,
+// rules depending on the field type are checked when field.Type is not nil,
+// rules depending on other fields are checked when structType is not nil
+func (i *Item) check(name string, vf VFunc, root, structType reflect.Type, field reflect.StructField) error {
+	if field.Type != nil {
+		if tc, ok := vf.(typeChecker); ok {
+			if err := tc.check(field.Type); err != nil {
+				return err
 			}
 		}
+		if elemRules[name] {
+			if err := i.elemRuleErr(field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	if fc, ok := vf.(fieldChecker); ok && structType != nil {
+		return fc.checkField(root, structType, field)
+	}
+	return nil
+}
+
+// Validate by fields, only the messages of the tag are returned
+func (i *Item) Validate(field reflect.StructField, value reflect.Value) (bool, string) {
+	info := &FieldInfo{Field: field, Value: value}
+	rules := i.cachedRules()
+	if !conditionMet(rules, info) {
+		return true, i.Msg
+	}
+	msg, errs := i.validate(context.Background(), rules, DefaultRegistry.lookup(i.Custom), info, false, nil, nil)
+	return len(errs) == 0, msg
+}
+
+// validate runs the rules and the custom validator of the item, stopping at the first failed rule when firstRule,
+// only the presence rules are run against an empty value when the item is omitempty, required or conditionally required,
+// it returns the item message and an error for every failed rule.
+// Failed rules get the catalog message in lang when the tag has no message or a message key, unless catalog is nil
+func (i *Item) validate(ctx context.Context, rules []*rule, custom VFunc, info *FieldInfo, firstRule bool, catalog *Catalog, lang []string) (string, []*FieldError) {
+	msg, value := i.Msg, info.Value
+	empty := skipEmpty(rules, info)
+	var errs []*FieldError
+	own := false
+	fail := func(vf VFunc, name, param, msg2 string) {
+		msg2 = strings.TrimSpace(msg2)
+		fe := &FieldError{info.Path, name, param, valueInterface(value), msg2}
+		var extra map[string]string
+		if tp, ok := vf.(templateParamer); ok {
+			extra = tp.templateParams()
+		}
+		if msg2 == "" {
+			fe.Message = i.Msg
+		}
+		if _, keyed := messageKey(fe.Message); catalog != nil && (fe.Message == "" || keyed) {
+			fe.Message = catalog.message(lang, info.Field.Name, fe, extra)
+		} else {
+			fe.Message = formatMessage(fe.Message, info.Field.Name, fe, extra)
+		}
+		// rule and default messages override the item message, the last failed one wins
+		ruleMsg := msg2 != "" || (i.Msg == "" && fe.Message != "")
+		if ruleMsg || !own {
+			msg, own = fe.Message, ruleMsg
+		}
+		errs = append(errs, fe)
+	}
+	for _, r := range rules {
+		if empty && !isPresenceRule(r.vf) {
+			continue
+		}
+		if passed, msg2 := validVFunc(ctx, r.vf, info); !passed {
+			if r.msg != "" {
+				msg2 = r.msg
+			}
+			fail(r.vf, r.name, r.param, msg2)
+			if firstRule {
+				return msg, errs
+			}
+		}
+	}
+	if custom != nil && !empty {
+		if passed, msg2 := validVFunc(ctx, custom, info); !passed {
+			fail(custom, "custom:"+i.Custom, "", msg2)
+		}
+	}
+	return msg, errs
+}
+
+// elemRuleErr return the error of an element rule on a field of type typ, if any
+func (i *Item) elemRuleErr(typ reflect.Type) error {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Map:
+		return errMapRule
+	case reflect.Slice, reflect.Array:
+		if i.Dive != "" {
+			return errDiveRule
+		}
+	}
+	return nil
+}
+
+func validVFunc(ctx context.Context, vf VFunc, info *FieldInfo) (bool, string) {
+	if cvf, ok := vf.(contextVFunc); ok {
+		return cvf.validContext(ctx, info)
 	}
-	return passed, msg
+	return vf.Valid(info.Value)
 }
@@ -12,7 +12,9 @@
 package validator
 
 import (
+	"context"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -20,7 +22,7 @@ func TestItemVF(t *testing.T) {
 	{
 		i := Item{}
 		vFs := i.vfs()
-		expectLen := 12
+		expectLen := 22
 		if len(vFs) != expectLen {
 			t.Fatalf("test failed: vFs length expect [%d], but got [%d]\n", expectLen, len(vFs))
 		}
@@ -32,9 +34,13 @@ func TestItemVF(t *testing.T) {
 	}
 
 	{
-		i := Item{"0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "F", ""}
+		i := Item{Min: "0", Max: "0", MinLength: "0", ArrMinLength: "0", MaxLength: "0", ArrMaxLength: "0",
+			Length: "0", ArrLength: "0", Enum: "0", Regex: "0", Msg: "0", Valid: "F",
+			EqField: "A", NeField: "A", GtField: "A", LtField: "A",
+			RequiredIf: "A a", RequiredUnless: "A a", RequiredWith: "A", RequiredWithout: "A",
+			Required: "T", OmitEmpty: "T"}
 		vFs := i.vfs()
-		expectLen := 12
+		expectLen := 22
 		if len(vFs) != expectLen {
 			t.Fatalf("test failed: vFs length expect [%d], but got [%d]\n", expectLen, len(vFs))
 		}
@@ -125,3 +131,59 @@ func TestItemValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestItemValidateMalformed(t *testing.T) {
+	i := &Item{Min: "A"}
+	passed, msg := i.Validate(reflect.StructField{}, reflect.ValueOf(1))
+	if passed || !strings.Contains(msg, "invalid min(A)") {
+		t.Fatalf("test failed: expect un-passed by the tag error, but got [%v %s]\n", passed, msg)
+	}
+}
+
+func TestRuleKinds(t *testing.T) {
+	// params failing the zero values of the kinds validated by the rules
+	params := map[string]string{
+		"min": "1", "max": "-1", "enum": "1", "regex": "^a$", "maxlength": "-1", "length": "1", "minlength": "1",
+		"size": "1", "minsize": "1", "maxsize": "-1", "valid": "true",
+	}
+	if len(params) != len(ruleKinds) {
+		t.Fatalf("test failed: expect a param for every rule of %v\n", ruleKinds)
+	}
+	values := []interface{}{0, int8(0), uint(0), uint64(0), uintptr(0), 0.0, float32(0), "", false, struct{}{},
+		[]int{}, [0]int{}, map[string]int{}, (*struct{})(nil)}
+	for name, param := range params {
+		kinds, _ := RuleKinds(name)
+		for _, v := range values {
+			value := reflect.ValueOf(v)
+			applies := false
+			for _, kind := range kinds {
+				applies = applies || kind == value.Kind()
+			}
+			if passed, _ := NewRule(name, param).vf.Valid(value); passed == applies {
+				t.Fatalf("test failed: %s(%s) on %T expect passed [%v], but got [%v]\n", name, param, v, !applies, passed)
+			}
+		}
+	}
+	if kinds, elems := RuleKinds("arr_length"); len(kinds) != 3 || elems || !IsElemRule("min") || IsElemRule("size") {
+		t.Fatalf("test failed: unexpected kinds %v [%v]\n", kinds, elems)
+	}
+}
+
+func BenchmarkItem_Validate(b *testing.B) {
+	i := &Item{MinLength: "1", MaxLength: "32", Regex: "^[a-zA-Z ]+$"}
+	value := reflect.ValueOf("John Doe")
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		i.Validate(reflect.StructField{}, value)
+	}
+}
+
+func BenchmarkItem_ValidateUncached(b *testing.B) {
+	i := &Item{MinLength: "1", MaxLength: "32", Regex: "^[a-zA-Z ]+$"}
+	value := reflect.ValueOf("John Doe")
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		rules, _ := i.compile(nil, nil, reflect.StructField{})
+		i.validate(context.Background(), rules, nil, &FieldInfo{Value: value}, false, DefaultCatalog, nil)
+	}
+}
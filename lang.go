@@ -0,0 +1,102 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// maxLangLen is the length of the longest language or Accept-Language header parsed,
+// the languages of longer headers are read up to it, longer languages are kept as they are
+const maxLangLen = 256
+
+// parseLangs return the languages wanted by values as canonical BCP 47 tags, e.g. zh-CN for zh_cn,
+// a value holding an Accept-Language header, e.g. "zh-TW,zh;q=0.9,en;q=0.8", gives its languages by quality.
+// Values that are not BCP 47 tags are kept as they are
+func parseLangs(values []string) []string {
+	langs := make([]string, 0, len(values))
+	for _, value := range values {
+		if !strings.ContainsAny(value, ",;") {
+			langs = append(langs, canonicalLang(value))
+			continue
+		}
+		if len(value) > maxLangLen {
+			// drop the languages after the last complete one
+			value = value[:maxLangLen]
+			value = value[:strings.LastIndexByte(value, ',')+1]
+			value = strings.TrimRight(value, ",")
+		}
+		tags, _, err := language.ParseAcceptLanguage(value)
+		if err != nil {
+			langs = append(langs, value)
+			continue
+		}
+		for _, tag := range tags {
+			if tag != language.Und {
+				langs = append(langs, tag.String())
+			}
+		}
+	}
+	return langs
+}
+
+// canonicalLang return the canonical BCP 47 tag of lang, or lang when it is not a tag
+func canonicalLang(lang string) string {
+	if len(lang) > maxLangLen {
+		return lang
+	}
+	if tag, err := language.Parse(lang); err == nil && lang != "" {
+		return tag.String()
+	}
+	return lang
+}
+
+// matchLang return the position of the language of the result matching value, a language or an Accept-Language header.
+// Languages given to Lang match exactly first, then by BCP 47 matching, e.g. zh-TW or zh match zh-CN
+func (r *Result) matchLang(value string) (int, bool) {
+	if pos, ok := r.lm[value]; ok {
+		return pos, true
+	}
+	wanted := parseLangs([]string{value})
+	for _, lang := range wanted {
+		if pos, ok := r.lm[lang]; ok {
+			return pos, true
+		}
+	}
+	var supported []language.Tag
+	var positions []int
+	for _, lang := range r.lang {
+		if pos, ok := r.lm[lang]; ok {
+			if tag, err := language.Parse(lang); err == nil {
+				supported, positions = append(supported, tag), append(positions, pos)
+			}
+		}
+	}
+	tags := make([]language.Tag, 0, len(wanted))
+	for _, lang := range wanted {
+		if len(lang) > maxLangLen {
+			continue
+		}
+		if tag, err := language.Parse(lang); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	if len(supported) == 0 || len(tags) == 0 {
+		return 0, false
+	}
+	if _, idx, confidence := language.NewMatcher(supported).Match(tags...); confidence != language.No {
+		return positions[idx], true
+	}
+	return 0, false
+}
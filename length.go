@@ -24,20 +24,21 @@ type lengthFunc struct {
 }
 
 // LengthFunc method
-func LengthFunc(str string) VFunc {
+func LengthFunc(str string) VFunc { return vFuncOf(parseLengthFunc(str)) }
+
+func parseLengthFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	vStr, msg := str, ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		vStr = str[:spIdx]
-		msg = str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
 	}
-	if v, err := strconv.ParseInt(vStr, 10, 64); err != nil {
-		panic(err)
-	} else {
-		return &lengthFunc{int(v), msg}
+	v, err := strconv.ParseInt(vStr, 10, 64)
+	if err != nil {
+		return nil, err
 	}
+	return &lengthFunc{int(v), msg}, nil
 }
 
 // Valid method
@@ -46,6 +47,9 @@ func (f *lengthFunc) Valid(value reflect.Value) (bool, string) {
 	typ := value.Type()
 	switch {
 	case reflectx.IsPtr(typ):
+		if value.IsNil() {
+			break
+		}
 		return f.Valid(value.Elem())
 	case reflectx.IsArray(typ) || reflectx.IsSlice(typ):
 		if reflectx.IsString(value.Type().Elem()) {
@@ -0,0 +1,245 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Syntax errors of validate tags, see SyntaxError
+var (
+	errUnbalanced   = errors.New("unbalanced parentheses")
+	errUnterminated = errors.New("unterminated quoted argument")
+	errAfterQuote   = errors.New("unexpected text after quoted argument")
+)
+
+// SyntaxError describes a malformed validate tag, it is the Err of the TagError of the tag
+type SyntaxError struct {
+	Column int   // Column is the 1-based byte column of the error in the parsed text
+	Err    error // Err is the syntax error
+}
+
+// Error method
+func (e *SyntaxError) Error() string { return fmt.Sprintf("column %d: %v", e.Column, e.Err) }
+
+// Unwrap method
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// tokenKind is the kind of a token of a validate tag
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokName             // name of a rule, letters, digits and underscores
+	tokLParen           // (
+	tokRParen           // )
+	tokComma            // , separating the param of a rule from its message
+	tokSep              // [,] the legacy separator of the param and the message
+	tokQuote            // ' starting a quoted argument
+	tokSpace            // spaces and tabs
+	tokText             // any other byte
+)
+
+// token is the text src[pos:end] of a validate tag
+type token struct {
+	kind     tokenKind
+	pos, end int
+}
+
+// lexer splits a validate tag into tokens
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() token {
+	start := l.pos
+	if start >= len(l.src) {
+		return token{tokEOF, start, start}
+	}
+	kind := tokText
+	l.pos++
+	switch c := l.src[start]; {
+	case c == '(':
+		kind = tokLParen
+	case c == ')':
+		kind = tokRParen
+	case c == ',':
+		kind = tokComma
+	case c == '\'':
+		kind = tokQuote
+	case strings.HasPrefix(l.src[start:], "[,]"):
+		kind, l.pos = tokSep, start+3
+	case c == ' ' || c == '\t':
+		kind = tokSpace
+		for l.pos < len(l.src) && (l.src[l.pos] == ' ' || l.src[l.pos] == '\t') {
+			l.pos++
+		}
+	case isNameByte(c):
+		kind = tokName
+		for l.pos < len(l.src) && isNameByte(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	return token{kind, start, l.pos}
+}
+
+// quoted scans a quoted argument after its opening quote, \' and \\ escape a quote and a backslash,
+// other backslashes are kept
+func (l *lexer) quoted() (string, *SyntaxError) {
+	open := l.pos - 1
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		l.pos++
+		switch {
+		case c == '\'':
+			return b.String(), nil
+		case c == '\\' && l.pos < len(l.src) && (l.src[l.pos] == '\'' || l.src[l.pos] == '\\'):
+			b.WriteByte(l.src[l.pos])
+			l.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", &SyntaxError{open + 1, errUnterminated}
+}
+
+// value scans the value of a rule up to its closing parenthesis, open is the position of its opening one.
+// It returns the param and the message of the value: they are separated by the first [,] outside of parentheses
+// and quoted arguments, or by the first comma when there is no such [,], arguments starting with a quote are unquoted.
+// Quoted arguments of nested rules, like those of keys(...), are skipped as they are and unquoted when the nested
+// rules are parsed
+func (l *lexer) value(open int) (string, string, *SyntaxError) {
+	sep := l.sepAhead()
+	split := func(kind tokenKind) bool { return kind == tokSep || kind == tokComma && !sep }
+	var args [2]string
+	arg, depth := 0, 0
+	argStart := true // at the start of an argument, only spaces were scanned
+	quoted := false  // the argument was quoted, only spaces may follow it
+	spaces := ""     // spaces at the start of an argument, dropped before a quote
+	for {
+		tok := l.next()
+		text := l.src[tok.pos:tok.end]
+		switch {
+		case tok.kind == tokEOF:
+			return "", "", &SyntaxError{open + 1, errUnbalanced}
+		case quoted:
+			switch {
+			case tok.kind == tokSpace:
+			case tok.kind == tokRParen:
+				return args[0], args[1], nil
+			case arg == 0 && split(tok.kind):
+				arg, argStart, quoted = 1, true, false
+			default:
+				return "", "", &SyntaxError{tok.pos + 1, errAfterQuote}
+			}
+		case tok.kind == tokSpace && argStart:
+			spaces += text
+		case tok.kind == tokQuote && argStart:
+			s, err := l.quoted()
+			if err != nil {
+				return "", "", err
+			}
+			if depth == 0 {
+				args[arg], quoted = s, true
+			} else {
+				args[arg] += spaces + l.src[tok.pos:l.pos]
+			}
+			spaces, argStart = "", false
+		case tok.kind == tokRParen && depth == 0:
+			args[arg] = strings.TrimRight(args[arg], " \t")
+			return args[0], args[1], nil
+		default:
+			if depth > 0 || arg > 0 {
+				// like the message, spaces of nested rules are kept
+				args[arg] += spaces
+			}
+			spaces, argStart = "", false
+			if depth == 0 && arg == 0 && split(tok.kind) {
+				arg, argStart = 1, true
+				continue
+			}
+			args[arg] += text
+			switch tok.kind {
+			case tokLParen:
+				depth++
+				argStart = true
+			case tokRParen:
+				depth--
+			case tokComma, tokSep:
+				argStart = depth > 0
+			}
+		}
+	}
+}
+
+// sepAhead reports whether the value at the position of l has a [,] outside of parentheses and quoted arguments,
+// the legacy separator then takes priority over commas, which may be part of the param, e.g. of a regex
+func (l *lexer) sepAhead() bool {
+	c := *l
+	depth, argStart := 0, true
+	for {
+		tok := c.next()
+		switch tok.kind {
+		case tokEOF:
+			return false
+		case tokSep:
+			if depth == 0 {
+				return true
+			}
+			argStart = true
+		case tokComma:
+			argStart = true
+		case tokQuote:
+			if argStart {
+				if _, err := c.quoted(); err != nil {
+					return false
+				}
+			}
+			argStart = false
+		case tokLParen:
+			depth++
+			argStart = true
+		case tokRParen:
+			if depth == 0 {
+				return false
+			}
+			depth--
+			argStart = false
+		case tokSpace:
+		default:
+			argStart = false
+		}
+	}
+}
+
+// SplitValue splits the value of a rule into its param and message, e.g. 10 and too small for min(10,too small),
+// see the package documentation for the grammar. A malformed value is returned as a *SyntaxError
+func SplitValue(value string) (param, msg string, err error) {
+	return splitMsg(value)
+}
+
+// splitMsg splits a rule value into its param and message
+func splitMsg(str string) (string, string, error) {
+	l := &lexer{src: str + ")"}
+	param, msg, err := l.value(0)
+	if err != nil {
+		return "", "", err
+	}
+	if l.pos != len(l.src) {
+		return "", "", &SyntaxError{l.pos, errUnbalanced}
+	}
+	return param, msg, nil
+}
@@ -22,7 +22,7 @@ func TestNewMaxFunc(t *testing.T) {
 	}
 }
 
-func TestNewMaxFuncPanic(t *testing.T) {
+func TestNewMaxFuncErr(t *testing.T) {
 	for _, tc := range []struct {
 		name string
 		str  string
@@ -31,16 +31,10 @@ func TestNewMaxFuncPanic(t *testing.T) {
 		{"ParseFloat", "A", parseNumError("ParseFloat", "A")},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			defer func() {
-				re := recover()
-				if re == nil {
-					t.Fatalf("%s failed: error expect not nil", t.Name())
-				}
-				if re == nil || !reflect.DeepEqual(tc.err, re) {
-					t.Fatalf("%s failed: error expect [%v], but got [%v]\n", t.Name(), tc.err, re)
-				}
-			}()
-			_ = MaxFunc(tc.str)
+			passed, msg := MaxFunc(tc.str).Valid(reflect.ValueOf(0))
+			if passed || msg != tc.err.Error() {
+				t.Fatalf("%s failed: expect [false %v], but got [%v %v]\n", t.Name(), tc.err, passed, msg)
+			}
 		})
 	}
 }
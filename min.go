@@ -24,21 +24,21 @@ type minFunc struct {
 }
 
 // MinFunc method
-func MinFunc(str string) VFunc {
+func MinFunc(str string) VFunc { return vFuncOf(parseMinFunc(str)) }
+
+func parseMinFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	floatStr := str
-	msg := ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		floatStr = str[:spIdx]
-		msg = str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
 	}
-	if v, err := strconv.ParseFloat(floatStr, 64); err != nil {
-		panic(err)
-	} else {
-		return &minFunc{v, msg}
+	v, err := strconv.ParseFloat(vStr, 64)
+	if err != nil {
+		return nil, err
 	}
+	return &minFunc{v, msg}, nil
 }
 
 // Valid method
@@ -47,6 +47,9 @@ func (f *minFunc) Valid(value reflect.Value) (bool, string) {
 	typ := value.Type()
 	switch {
 	case reflectx.IsPtr(typ):
+		if value.IsNil() {
+			break
+		}
 		return f.Valid(value.Elem())
 	case reflectx.IsArray(typ) || reflectx.IsSlice(typ):
 		for i := 0; i < value.Len(); i++ {
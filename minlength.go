@@ -24,20 +24,21 @@ type minLengthFunc struct {
 }
 
 // MinLengthFunc method
-func MinLengthFunc(str string) VFunc {
+func MinLengthFunc(str string) VFunc { return vFuncOf(parseMinLengthFunc(str)) }
+
+func parseMinLengthFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	vStr, msg := str, ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		vStr = str[:spIdx]
-		msg = str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
 	}
-	if v, err := strconv.ParseInt(vStr, 10, 64); err != nil {
-		panic(err)
-	} else {
-		return &minLengthFunc{int(v), msg}
+	v, err := strconv.ParseInt(vStr, 10, 64)
+	if err != nil {
+		return nil, err
 	}
+	return &minLengthFunc{int(v), msg}, nil
 }
 
 // Valid method
@@ -46,6 +47,9 @@ func (f *minLengthFunc) Valid(value reflect.Value) (bool, string) {
 	typ := value.Type()
 	switch {
 	case reflectx.IsPtr(typ):
+		if value.IsNil() {
+			break
+		}
 		return f.Valid(value.Elem())
 	case reflectx.IsArray(typ) || reflectx.IsSlice(typ):
 		if reflectx.IsString(value.Type().Elem()) {
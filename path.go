@@ -0,0 +1,65 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NameFunc return the name of a field in ResultItem.Path,
+// an empty name falls back to the Go field name and promotes the fields of an embedded struct
+type NameFunc func(field reflect.StructField) string
+
+// GoName names fields by their Go name
+func GoName(field reflect.StructField) string {
+	if field.Anonymous {
+		return ""
+	}
+	return field.Name
+}
+
+// JSONName names fields by their json tag name
+func JSONName(field reflect.StructField) string {
+	name := field.Tag.Get("json")
+	if idx := strings.Index(name, ","); idx != -1 {
+		name = name[:idx]
+	}
+	if name == "-" || (name == "" && field.Anonymous) {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func joinPath(path, name, fieldName string) string {
+	if name == "" {
+		name = fieldName
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func indexPath(path string, i int) string { return path + "[" + strconv.Itoa(i) + "]" }
+
+func keyPath(path string, key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return path + "[" + strconv.Quote(key.String()) + "]"
+	}
+	return path + "[" + fmt.Sprint(key) + "]"
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNameFunc(t *testing.T) {
+	typ := reflect.TypeOf(struct {
+		testNestedEmbed
+		Named    testNestedEmbed `json:"named"`
+		Name     string          `json:"name,omitempty"`
+		Age      int             `json:",omitempty"`
+		Ignored  int             `json:"-"`
+		Untagged int
+	}{})
+	for _, tc := range []struct {
+		name   string
+		field  string
+		goName string
+		json   string
+	}{
+		{"Embedded", "testNestedEmbed", "", ""},
+		{"EmbeddedNamed", "Named", "Named", "named"},
+		{"Name", "Name", "Name", "name"},
+		{"NoName", "Age", "Age", "Age"},
+		{"Ignored", "Ignored", "Ignored", ""},
+		{"Untagged", "Untagged", "Untagged", "Untagged"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			field, _ := typ.FieldByName(tc.field)
+			if name := GoName(field); name != tc.goName {
+				t.Fatalf("%s failed: GoName expect [%s], but got [%s]\n", t.Name(), tc.goName, name)
+			}
+			if name := JSONName(field); name != tc.json {
+				t.Fatalf("%s failed: JSONName expect [%s], but got [%s]\n", t.Name(), tc.json, name)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		path   string
+		expect string
+	}{
+		{"Join", joinPath("Order", "Items", "Items"), "Order.Items"},
+		{"JoinRoot", joinPath("", "Items", "Items"), "Items"},
+		{"JoinFallback", joinPath("Order", "", "Items"), "Order.Items"},
+		{"Index", indexPath("Items", 3), "Items[3]"},
+		{"StringKey", keyPath("Meta", reflect.ValueOf("region")), `Meta["region"]`},
+		{"IntKey", keyPath("Meta", reflect.ValueOf(10)), "Meta[10]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.path != tc.expect {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.expect, tc.path)
+			}
+		})
+	}
+}
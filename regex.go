@@ -19,29 +19,40 @@ import (
 
 // regexFunc struct
 type regexFunc struct {
-	patten string
-	msg    string
+	re  *regexp.Regexp
+	msg string
 }
 
 // RegexFunc method
-func RegexFunc(str string) VFunc {
+func RegexFunc(str string) VFunc { return vFuncOf(parseRegexFunc(str)) }
+
+// RegexpFunc method, the message is given by Rule.Msg
+func RegexpFunc(re *regexp.Regexp) VFunc { return &regexFunc{re: re} }
+
+func parseRegexFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	vStr, msg := str, ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		vStr, msg = str[:spIdx], str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
 	}
-	return &regexFunc{vStr, msg}
+	re, err := regexp.Compile(vStr)
+	if err != nil {
+		return nil, err
+	}
+	return &regexFunc{re, msg}, nil
 }
 
 // Valid method
 func (f *regexFunc) Valid(value reflect.Value) (bool, string) {
 	passed, msg := true, f.msg
-	re := regexp.MustCompile(f.patten)
 	typ := value.Type()
 	switch {
 	case reflectx.IsPtr(typ):
+		if value.IsNil() {
+			break
+		}
 		return f.Valid(value.Elem())
 	case reflectx.IsArray(typ) || reflectx.IsSlice(typ):
 		for i := 0; i < value.Len(); i++ {
@@ -51,7 +62,7 @@ func (f *regexFunc) Valid(value reflect.Value) (bool, string) {
 			}
 		}
 	case reflectx.IsString(typ):
-		passed = re.MatchString(value.String())
+		passed = f.re.MatchString(value.String())
 	}
 	return passed, msg
 }
@@ -0,0 +1,191 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ErrProblemTitle is returned by a ProblemRenderer with a Status but no Title
+var ErrProblemTitle = errors.New("validator: ProblemRenderer with a Status needs a Title")
+
+// The status and title of the problems of Result.Problem
+const (
+	problemStatus = 400
+	problemTitle  = "Bad Request"
+)
+
+// DefaultRenderer is used by Result.Render
+var DefaultRenderer Renderer = ProblemRenderer{}
+
+// Renderer renders results, so every service returns validation errors in the same shape
+type Renderer interface {
+	// ContentType return the media type of the rendered results, e.g. application/json
+	ContentType() string
+	// Render writes the result in lang, a language or an Accept-Language header matched like Result.Messages
+	Render(w io.Writer, r *Result, lang string) error
+}
+
+// Render writes the result in lang with DefaultRenderer
+func (r *Result) Render(w io.Writer, lang string) error { return DefaultRenderer.Render(w, r, lang) }
+
+// Map return the messages of the failed rules by path, e.g. {"Items[3].Sku": ["Sku is required"]},
+// in lang like Messages, without duplicates. A failed field without rule messages has its own message, if any
+func (r *Result) Map(lang ...string) map[string][]string {
+	langPos := r.langPos(lang)
+	fields := make(map[string][]string)
+	for _, item := range r.Items {
+		if item.Passed {
+			continue
+		}
+		path := resultPath(item)
+		messages := fields[path]
+		if messages == nil {
+			messages = []string{}
+		}
+		count := len(messages)
+		for _, fe := range item.Errors {
+			messages = appendMessage(messages, fe.Message, langPos)
+		}
+		if len(messages) == count {
+			messages = appendMessage(messages, item.Message, langPos)
+		}
+		fields[path] = messages
+	}
+	return fields
+}
+
+// appendMessage appends the translation of msg at langPos to messages, unless msg is empty or already in messages
+func appendMessage(messages []string, msg string, langPos int) []string {
+	if msg == "" {
+		return messages
+	}
+	msg = localize(msg, langPos)
+	for _, m := range messages {
+		if m == msg {
+			return messages
+		}
+	}
+	return append(messages, msg)
+}
+
+// resultPath return the path of a result item, or its field name
+func resultPath(item *ResultItem) string {
+	if item.Path == "" && item.Field != nil {
+		return item.Field.Name
+	}
+	return item.Path
+}
+
+// resultJSON is the JSON document of a result
+type resultJSON struct {
+	Passed bool                `json:"passed"`
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// MarshalJSON encodes the result as {"passed": false, "errors": {"Name": ["Name is required"]}},
+// messages are in the first language of Validator.Lang
+func (r *Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resultJSON{r.Passed, r.Map()})
+}
+
+// JSONRenderer renders results like Result.MarshalJSON, with the messages in the language given to Render
+type JSONRenderer struct{}
+
+// ContentType method
+func (JSONRenderer) ContentType() string { return "application/json" }
+
+// Render method
+func (JSONRenderer) Render(w io.Writer, r *Result, lang string) error {
+	return json.NewEncoder(w).Encode(resultJSON{r.Passed, r.Map(lang)})
+}
+
+// Problem is an RFC 7807 problem details document, invalid-params holds every failed rule
+type Problem struct {
+	Type          string         `json:"type,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// InvalidParam is a failed rule of a Problem
+type InvalidParam struct {
+	Name   string `json:"name"`           // Name is the path of the field
+	Reason string `json:"reason"`         // Reason is the message of the rule
+	Rule   string `json:"rule,omitempty"` // Rule is the failed rule, e.g. min
+}
+
+// Problem return the problem details of the result in lang like Messages, a Bad Request about:blank problem,
+// or nil when the result passed. Failed fields without failed rules give an invalid param with their own message
+func (r *Result) Problem(lang ...string) *Problem {
+	if r.Passed {
+		return nil
+	}
+	langPos := r.langPos(lang)
+	problem := &Problem{
+		Type:          "about:blank",
+		Title:         problemTitle,
+		Status:        problemStatus,
+		InvalidParams: []InvalidParam{},
+	}
+	for _, item := range r.Items {
+		if item.Passed {
+			continue
+		}
+		if len(item.Errors) == 0 {
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{resultPath(item), localize(item.Message, langPos), ""})
+		}
+		for _, fe := range item.Errors {
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{resultPath(item), localize(fe.Message, langPos), fe.Rule})
+		}
+	}
+	return problem
+}
+
+// ProblemRenderer renders un-passed results as RFC 7807 application/problem+json documents, see Result.Problem,
+// its non-zero fields replace the fields of the problem. Passed results are not problems, nothing is written for them
+type ProblemRenderer struct {
+	Type     string // Type is a URI identifying the problem, about:blank by default
+	Title    string // Title is a summary of the problem, Bad Request by default, it is required with Status
+	Status   int    // Status is the HTTP status code, 400 by default
+	Detail   string // Detail explains the problem
+	Instance string // Instance is a URI identifying the occurrence of the problem
+}
+
+// ContentType method
+func (ProblemRenderer) ContentType() string { return "application/problem+json" }
+
+// Render method
+func (p ProblemRenderer) Render(w io.Writer, r *Result, lang string) error {
+	if p.Status != 0 && p.Title == "" {
+		return ErrProblemTitle
+	}
+	problem := r.Problem(lang)
+	if problem == nil {
+		return nil
+	}
+	if p.Type != "" {
+		problem.Type = p.Type
+	}
+	if p.Status != 0 {
+		problem.Status = p.Status
+	}
+	if p.Title != "" {
+		problem.Title = p.Title
+	}
+	problem.Detail, problem.Instance = p.Detail, p.Instance
+	return json.NewEncoder(w).Encode(problem)
+}
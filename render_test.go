@@ -0,0 +1,124 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testRender struct {
+	Name  string           `validate:"required(true,请输入姓名|name is required)"`
+	Age   int              `validate:"min(18) max(10) msg(年龄错误|bad age)"`
+	Items []testRenderItem `validate:"minsize(2)"`
+}
+
+type testRenderItem struct {
+	Sku string `validate:"minlength(3)"`
+}
+
+func newTestRender() *Result {
+	return New(&testRender{Age: 12, Items: []testRenderItem{{"ab"}}}).Lang("zh-CN", "en").Validate()
+}
+
+func TestResult_Map(t *testing.T) {
+	r := newTestRender()
+	expect := map[string][]string{
+		"Name":         {"请输入姓名"},
+		"Age":          {"年龄错误"},
+		"Items":        {"Items至少需要2个元素"},
+		"Items[0].Sku": {"Sku的长度不能小于3"},
+	}
+	if m := r.Map(); !reflect.DeepEqual(m, expect) {
+		t.Fatalf("test failed: expect %v, but got %v\n", expect, m)
+	}
+	if m := r.Map("en-US"); !reflect.DeepEqual(m["Age"], []string{"bad age"}) || !reflect.DeepEqual(m["Name"], []string{"name is required"}) {
+		t.Fatalf("test failed: expect the en messages, but got %v\n", m)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if m := New(&testRender{}).ValidateContext(cancelled).Map(); len(m) != 0 {
+		t.Fatalf("test failed: expect no messages, but got %v\n", m)
+	}
+	r = NewResult(nil, []*ResultItem{{Path: "A", Message: "a"}, {Path: "B"}, {Path: "A", Errors: []*FieldError{{Message: "c"}}}})
+	if m := r.Map(); !reflect.DeepEqual(m, map[string][]string{"A": {"a", "c"}, "B": {}}) {
+		t.Fatalf("test failed: expect item messages, but got %v\n", m)
+	}
+}
+
+func TestResult_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(New(&testRender{Name: "go", Age: 1, Items: []testRenderItem{{"abc"}, {"abc"}}}).Validate())
+	if err != nil {
+		t.Fatalf("test failed: %v\n", err)
+	}
+	if expect := `{"passed":false,"errors":{"Age":["年龄错误"]}}`; string(data) != expect {
+		t.Fatalf("test failed: expect [%s], but got [%s]\n", expect, data)
+	}
+	if data, _ = json.Marshal(Var(1, "min(1)")); string(data) != `{"passed":true}` {
+		t.Fatalf("test failed: expect [{\"passed\":true}], but got [%s]\n", data)
+	}
+}
+
+func TestRenderers(t *testing.T) {
+	r := newTestRender()
+	for _, tc := range []struct {
+		name        string
+		renderer    Renderer
+		contentType string
+		expect      string
+	}{
+		{"JSON", JSONRenderer{}, "application/json",
+			`{"passed":false,"errors":{"Age":["bad age"],"Items":["Items must have at least 2 elements"],"Items[0].Sku":["Sku must have a length of at least 3"],"Name":["name is required"]}}`},
+		{"Problem", ProblemRenderer{}, "application/problem+json",
+			`{"type":"about:blank","title":"Bad Request","status":400,"invalid-params":[{"name":"Name","reason":"name is required","rule":"required"},` +
+				`{"name":"Age","reason":"bad age","rule":"min"},{"name":"Age","reason":"bad age","rule":"max"},` +
+				`{"name":"Items","reason":"Items must have at least 2 elements","rule":"minsize"},{"name":"Items[0].Sku","reason":"Sku must have a length of at least 3","rule":"minlength"}]}`},
+		{"ProblemFields", ProblemRenderer{Type: "https://example.com/validation", Title: "Invalid request", Status: 422, Detail: "fix the fields", Instance: "/users"}, "application/problem+json",
+			`{"type":"https://example.com/validation","title":"Invalid request","status":422,"detail":"fix the fields","instance":"/users","invalid-params":[{"name":"Name","reason":"name is required","rule":"required"},` +
+				`{"name":"Age","reason":"bad age","rule":"min"},{"name":"Age","reason":"bad age","rule":"max"},` +
+				`{"name":"Items","reason":"Items must have at least 2 elements","rule":"minsize"},{"name":"Items[0].Sku","reason":"Sku must have a length of at least 3","rule":"minlength"}]}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tc.renderer.Render(&buf, r, "en;q=0.9,fr"); err != nil {
+				t.Fatalf("%s failed: %v\n", t.Name(), err)
+			}
+			if got := bytes.TrimSpace(buf.Bytes()); string(got) != tc.expect {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.expect, got)
+			}
+			if ct := tc.renderer.ContentType(); ct != tc.contentType {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.contentType, ct)
+			}
+		})
+	}
+	var buf bytes.Buffer
+	if err := Var("", "required(true)").Render(&buf, ""); err != nil || buf.String() != `{"type":"about:blank","title":"Bad Request","status":400,"invalid-params":[{"name":"Value","reason":"Value is required","rule":"required"}]}`+"\n" {
+		t.Fatalf("test failed: expect the DefaultRenderer problem, but got [%s] [%v]\n", buf.String(), err)
+	}
+	buf.Reset()
+	if err := (ProblemRenderer{Status: 422}).Render(&buf, r, ""); !errors.Is(err, ErrProblemTitle) || buf.Len() != 0 {
+		t.Fatalf("test failed: expect [%v], but got [%s] [%v]\n", ErrProblemTitle, buf.String(), err)
+	}
+	if err := Var("a", "required(true)").Render(&buf, ""); err != nil || buf.Len() != 0 {
+		t.Fatalf("test failed: expect nothing for a passed result, but got [%s] [%v]\n", buf.String(), err)
+	}
+	if p := Var("a", "required(true)").Problem(); p != nil {
+		t.Fatalf("test failed: expect a nil problem, but got %v\n", p)
+	}
+	if p := NewResult(nil, []*ResultItem{{Path: "A", Message: "a|b"}}).Problem(); !reflect.DeepEqual(p.InvalidParams, []InvalidParam{{"A", "a", ""}}) {
+		t.Fatalf("test failed: expect the item message, but got %v\n", p.InvalidParams)
+	}
+}
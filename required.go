@@ -0,0 +1,162 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Emptier is implemented by types deciding themselves whether they are empty,
+// it overrides the emptiness used by required, omitempty and the conditional rules
+type Emptier interface {
+	IsEmpty() bool
+}
+
+// zeroer is implemented by types like time.Time knowing their zero value
+type zeroer interface {
+	IsZero() bool
+}
+
+// requiredFunc struct
+type requiredFunc struct {
+	required bool
+	msg      string
+}
+
+// RequiredFunc method
+func RequiredFunc(str string) VFunc { return vFuncOf(parseRequiredFunc(str)) }
+
+func parseRequiredFunc(str string) (VFunc, error) {
+	if str == "" {
+		return nil, nil
+	}
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
+	}
+	v, err := strconv.ParseBool(vStr)
+	if err != nil {
+		return nil, err
+	}
+	return &requiredFunc{v, msg}, nil
+}
+
+// Valid method
+func (f *requiredFunc) Valid(value reflect.Value) (bool, string) {
+	if f.required && isEmpty(value) {
+		return false, f.msg
+	}
+	return true, ""
+}
+
+// omitEmptyFunc marks the rules of a field to be skipped when its value is empty
+type omitEmptyFunc struct {
+	omit bool
+}
+
+func parseOmitEmptyFunc(str string) (VFunc, error) {
+	if str == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(str)
+	if err != nil {
+		return nil, err
+	}
+	return &omitEmptyFunc{v}, nil
+}
+
+// Valid method
+func (f *omitEmptyFunc) Valid(reflect.Value) (bool, string) { return true, "" }
+
+// isPresenceRule reports whether the rule checks the presence of the value,
+// they are the only rules run against an empty value that is omitempty or required
+func isPresenceRule(vf VFunc) bool {
+	switch vf.(type) {
+	case *requiredFunc, *requiredIfFunc:
+		return true
+	}
+	return false
+}
+
+// skipEmpty reports whether only the presence rules have to be run against the value of the field,
+// that is when the value is empty and the field is omitempty, required or conditionally required with its condition met,
+// or when the value is missing
+func skipEmpty(rules []*rule, info *FieldInfo) bool {
+	value := info.Value
+	if !value.IsValid() {
+		return true
+	}
+	for _, r := range rules {
+		switch f := r.vf.(type) {
+		case *omitEmptyFunc:
+			if f.omit {
+				return isEmpty(value)
+			}
+		case *requiredFunc:
+			if f.required {
+				return isEmpty(value)
+			}
+		case *requiredIfFunc:
+			if f.met(info) {
+				return isEmpty(value)
+			}
+		}
+	}
+	return false
+}
+
+// isEmpty reports whether the value is empty:
+// nil pointers, interfaces, slices, maps and chans, strings, slices, maps and chans without elements,
+// zero values of other kinds and types with an IsZero method reporting true, like time.Time;
+// pointers are dereferenced at every level and Emptier overrides all of them
+func isEmpty(value reflect.Value) bool {
+	for value.IsValid() {
+		if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+			return true
+		}
+		if empty, ok := emptyMethod(value); ok {
+			return empty
+		}
+		switch value.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			value = value.Elem()
+			continue
+		case reflect.Slice, reflect.Map, reflect.String, reflect.Chan:
+			return value.Len() == 0
+		}
+		return value.IsZero()
+	}
+	return true
+}
+
+// emptyMethod return the emptiness reported by the Emptier or IsZero method of the value, if any
+func emptyMethod(value reflect.Value) (bool, bool) {
+	if !value.CanInterface() {
+		return false, false
+	}
+	values := []reflect.Value{value}
+	if value.CanAddr() {
+		values = append(values, value.Addr())
+	}
+	for _, v := range values {
+		if e, ok := v.Interface().(Emptier); ok {
+			return e.IsEmpty(), true
+		}
+	}
+	for _, v := range values {
+		if z, ok := v.Interface().(zeroer); ok {
+			return z.IsZero(), true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// requiredIfFunc requires a field depending on other fields, the field is skipped entirely when its condition is not met
+type requiredIfFunc struct {
+	fields []string
+	values []string // values are nil for required_with and required_without
+	unless bool     // unless negates the condition of required_if
+	absent bool     // absent negates the condition of required_with
+	msg    string
+}
+
+// RequiredIfFunc method
+func RequiredIfFunc(str string) VFunc { return vFuncOf(parseRequiredIfFunc(str)) }
+
+// RequiredUnlessFunc method
+func RequiredUnlessFunc(str string) VFunc { return vFuncOf(parseRequiredUnlessFunc(str)) }
+
+// RequiredWithFunc method
+func RequiredWithFunc(str string) VFunc { return vFuncOf(parseRequiredWithFunc(str)) }
+
+// RequiredWithoutFunc method
+func RequiredWithoutFunc(str string) VFunc { return vFuncOf(parseRequiredWithoutFunc(str)) }
+
+// RequiredIfValueFunc method, the path and value are taken as they are, spaces included,
+// the message is given by Rule.Msg
+func RequiredIfValueFunc(path, value string) VFunc {
+	return &requiredIfFunc{fields: []string{path}, values: []string{value}}
+}
+
+// RequiredUnlessValueFunc method, the path and value are taken as they are, spaces included,
+// the message is given by Rule.Msg
+func RequiredUnlessValueFunc(path, value string) VFunc {
+	return &requiredIfFunc{fields: []string{path}, values: []string{value}, unless: true}
+}
+
+func parseRequiredIfFunc(str string) (VFunc, error) { return parseRequiredPairs(str, false) }
+
+func parseRequiredUnlessFunc(str string) (VFunc, error) { return parseRequiredPairs(str, true) }
+
+func parseRequiredWithFunc(str string) (VFunc, error) { return parseRequiredFields(str, false) }
+
+func parseRequiredWithoutFunc(str string) (VFunc, error) { return parseRequiredFields(str, true) }
+
+// parseRequiredPairs parses `Field value [Field value...]`
+func parseRequiredPairs(str string, unless bool) (VFunc, error) {
+	if str == "" {
+		return nil, nil
+	}
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
+	}
+	params := strings.Fields(vStr)
+	if len(params) == 0 || len(params)%2 != 0 {
+		return nil, fmt.Errorf("expect field and value pairs, but got %q", vStr)
+	}
+	f := &requiredIfFunc{unless: unless, msg: msg}
+	for i := 0; i < len(params); i += 2 {
+		f.fields = append(f.fields, params[i])
+		f.values = append(f.values, params[i+1])
+	}
+	return f, nil
+}
+
+// parseRequiredFields parses `Field [Field...]`
+func parseRequiredFields(str string, absent bool) (VFunc, error) {
+	if str == "" {
+		return nil, nil
+	}
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(vStr)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("expect fields, but got %q", vStr)
+	}
+	return &requiredIfFunc{fields: fields, absent: absent, msg: msg}, nil
+}
+
+// Valid method, the condition cannot be resolved without a parent struct and is never met
+func (f *requiredIfFunc) Valid(value reflect.Value) (bool, string) {
+	return f.validContext(context.Background(), &FieldInfo{Value: value})
+}
+
+func (f *requiredIfFunc) validContext(_ context.Context, info *FieldInfo) (bool, string) {
+	if f.met(info) && isEmpty(info.Value) {
+		return false, f.msg
+	}
+	return true, ""
+}
+
+// met reports whether the condition requiring the field is met
+func (f *requiredIfFunc) met(info *FieldInfo) bool {
+	if f.values == nil {
+		// required_with: any field present, required_without: any field absent
+		for _, field := range f.fields {
+			other, ok := fieldValue(info, field)
+			if (ok && !isEmpty(other)) != f.absent {
+				return true
+			}
+		}
+		return false
+	}
+	// required_if: every field equals its value, required_unless: not all of them do
+	for i, field := range f.fields {
+		other, ok := fieldValue(info, field)
+		if !ok || valueString(other) != f.values[i] {
+			return f.unless
+		}
+	}
+	return !f.unless
+}
+
+// templateParams gives {conditions}, the conditions requiring the field
+func (f *requiredIfFunc) templateParams() map[string]string {
+	conditions := make([]string, len(f.fields))
+	for i, field := range f.fields {
+		switch {
+		case f.values != nil:
+			conditions[i] = field + " is " + f.values[i]
+		case f.absent:
+			conditions[i] = field + " is missing"
+		default:
+			conditions[i] = field + " is present"
+		}
+	}
+	sep := " or "
+	if f.values != nil {
+		sep = " and "
+	}
+	return map[string]string{"conditions": strings.Join(conditions, sep)}
+}
+
+func (f *requiredIfFunc) checkField(root, structType reflect.Type, _ reflect.StructField) error {
+	for _, field := range f.fields {
+		if _, ok := typeByPath(structType, field); !ok {
+			if _, ok = typeByPath(root, field); !ok {
+				return fmt.Errorf("unknown field %s", field)
+			}
+		}
+	}
+	return nil
+}
+
+// conditionMet reports whether a field with the rules has to be validated,
+// a field with conditional rules is skipped when none of their conditions is met
+func conditionMet(rules []*rule, info *FieldInfo) bool {
+	conditional := false
+	for _, r := range rules {
+		if f, ok := r.vf.(*requiredIfFunc); ok {
+			if f.met(info) {
+				return true
+			}
+			conditional = true
+		}
+	}
+	return !conditional
+}
+
+// fieldValue return the field reached by the dotted path,
+// resolved from the struct declaring the field first, then from the validated struct
+func fieldValue(info *FieldInfo, path string) (reflect.Value, bool) {
+	if value, ok := valueByPath(info.Parent, path); ok {
+		return value, true
+	}
+	return valueByPath(info.Root, path)
+}
+
+// valueString formats the value for comparisons with tag values, nil pointers are empty
+func valueString(value reflect.Value) string {
+	if value = derefValue(value); !value.IsValid() {
+		return ""
+	}
+	if v := valueInterface(value); v != nil {
+		return fmt.Sprint(v)
+	}
+	return fmt.Sprint(value)
+}
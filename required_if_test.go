@@ -0,0 +1,152 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testPayment struct {
+	PaymentMethod string
+	CardNumber    string  `validate:"required_if(PaymentMethod card) length(16,card number)"`
+	Voucher       *string `validate:"required_unless(PaymentMethod card)"`
+}
+
+type testContact struct {
+	Email string
+	Phone string `validate:"required_without(Email)"`
+	Area  int    `validate:"required_with(Phone)"`
+}
+
+func TestRequiredIf(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+		paths     []string
+		msg       string
+	}{
+		{"IfMet", &testPayment{"card", "", stringPtr("")}, []string{"CardNumber"},
+			"CardNumber is required when PaymentMethod is card"},
+		{"IfMetAndPass", &testPayment{"card", "1234567812345678", nil}, []string{}, ""},
+		{"IfMetAndOtherRule", &testPayment{"card", "1234", nil}, []string{"CardNumber"}, "card number"},
+		{"IfNotMetSkipped", &testPayment{"cash", "1234", stringPtr("a")}, []string{}, ""},
+		{"UnlessMet", &testPayment{"cash", "", nil}, []string{"Voucher"},
+			"Voucher is required unless PaymentMethod is card"},
+		{"UnlessMetEmpty", &testPayment{"cash", "", stringPtr("")}, []string{"Voucher"},
+			"Voucher is required unless PaymentMethod is card"},
+		{"WithoutMet", &testContact{}, []string{"Phone"}, "Phone is required when Email is missing"},
+		{"WithoutNotMet", &testContact{Email: "a"}, []string{}, ""},
+		{"WithMet", &testContact{Phone: "1"}, []string{"Area"}, "Area is required when Phone is present"},
+		{"WithMetAndPass", &testContact{Phone: "1", Area: 1}, []string{}, ""},
+		{"IfPairs", &struct {
+			Country string
+			Kind    int
+			Tax     string `validate:"required_if(Country CN Kind 1)"`
+		}{"CN", 1, ""}, []string{"Tax"}, "Tax is required when Country is CN and Kind is 1"},
+		{"IfPairsNotMet", &struct {
+			Country string
+			Kind    int
+			Tax     string `validate:"required_if(Country CN Kind 1)"`
+		}{"CN", 2, ""}, []string{}, ""},
+		{"IfMsg", &struct {
+			Kind int
+			Tax  string `validate:"required_if(Kind 1,tax)"`
+		}{1, ""}, []string{"Tax"}, "tax"},
+		{"IfRoot", &struct {
+			Kind  int
+			Lines []struct {
+				Tax string `validate:"required_if(Kind 1)"`
+			}
+		}{1, []struct {
+			Tax string `validate:"required_if(Kind 1)"`
+		}{{""}, {"a"}}}, []string{"Lines[0].Tax"}, "Tax is required when Kind is 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New(tc.structPtr)
+			if err := v.Err(); err != nil {
+				t.Fatalf("%s failed: expect nil err, but got [%v]\n", t.Name(), err)
+			}
+			r := v.Validate()
+			paths := make([]string, 0)
+			for _, item := range r.Items {
+				if !item.Passed {
+					paths = append(paths, item.Path)
+				}
+			}
+			if !reflect.DeepEqual(paths, tc.paths) {
+				t.Fatalf("%s failed: paths expect %q, but got %q\n", t.Name(), tc.paths, paths)
+			}
+			if r.Passed != (len(tc.paths) == 0) {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), len(tc.paths) == 0, r.Passed)
+			}
+			if tc.msg != r.Messages() {
+				t.Fatalf("%s failed: msg expect [%v], but got [%v]\n", t.Name(), tc.msg, r.Messages())
+			}
+		})
+	}
+}
+
+func TestRequiredIfSkipped(t *testing.T) {
+	r := New(&testPayment{"cash", "1234", stringPtr("a")}).Validate()
+	for _, item := range r.Items {
+		if item.Path == "CardNumber" {
+			t.Fatal("test failed: expect CardNumber skipped")
+		}
+	}
+	r = New(&testPayment{"card", "", nil}).Validate()
+	if err := r.Err(); !errors.Is(err, ErrRequiredIf) || errors.Is(err, ErrLength) {
+		t.Fatalf("test failed: expect only a required_if error, but got [%v]\n", err)
+	}
+	if msg := r.Messages(); msg != "CardNumber is required when PaymentMethod is card" {
+		t.Fatalf("test failed: expect only the required_if message, but got [%s]\n", msg)
+	}
+}
+
+func TestRequiredIfErr(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+	}{
+		{"Unknown", &struct {
+			A string `validate:"required_if(B b)"`
+		}{}},
+		{"Odd", &struct {
+			A string
+			B string `validate:"required_if(A a A)"`
+		}{}},
+		{"UnknownWith", &struct {
+			A string `validate:"required_with(B)"`
+		}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := New(tc.structPtr).Err(); !errors.Is(err, ErrTag) {
+				t.Fatalf("%s failed: expect err, but got [%v]\n", t.Name(), err)
+			}
+		})
+	}
+	if _, err := parseRequiredWithFunc(",fail"); err == nil {
+		t.Fatal("test failed: expect empty fields err")
+	}
+}
+
+func TestRequiredIfValid(t *testing.T) {
+	for _, s := range []testCase{
+		{"IfNoParent", RequiredIfFunc("A a"), reflect.ValueOf(""), true, ""},
+		{"UnlessNoParent", RequiredUnlessFunc("A a"), reflect.ValueOf(""), false, ""},
+		{"WithNoParent", RequiredWithFunc("A"), reflect.ValueOf(""), true, ""},
+		{"WithoutNoParent", RequiredWithoutFunc("A,fail"), reflect.ValueOf(""), false, "fail"},
+	} {
+		t.Run(s.name, s.test)
+	}
+}
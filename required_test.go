@@ -0,0 +1,160 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testEmptier struct{ ID string }
+
+func (e testEmptier) IsEmpty() bool { return e.ID == "" || e.ID == "none" }
+
+type testPtrEmptier struct{ ID string }
+
+func (e *testPtrEmptier) IsEmpty() bool { return e.ID == "none" }
+
+func TestNewRequiredFunc(t *testing.T) {
+	if v := RequiredFunc(""); v != nil {
+		t.Fatal("test failed")
+	}
+	if _, err := parseRequiredFunc("A"); err == nil {
+		t.Fatal("test failed: expect ParseBool err")
+	}
+	if _, err := parseOmitEmptyFunc("A"); err == nil {
+		t.Fatal("test failed: expect ParseBool err")
+	}
+}
+
+func TestRequired(t *testing.T) {
+	ptr := intPtr(0)
+	for _, s := range []testCase{
+		{"False", RequiredFunc("F"), reflect.ValueOf(""), true, ""},
+		{"String", RequiredFunc("T"), reflect.ValueOf("a"), true, ""},
+		{"EmptyString", RequiredFunc("T,fail"), reflect.ValueOf(""), false, "fail"},
+		{"Zero", RequiredFunc("T"), reflect.ValueOf(0), false, ""},
+		{"PtrZero", RequiredFunc("T"), reflect.ValueOf(&ptr), false, ""},
+		{"NilPtrPtr", RequiredFunc("T"), reflect.ValueOf((**int)(nil)), false, ""},
+		{"PtrPtr", RequiredFunc("T"), reflect.ValueOf(func() **int { p := intPtr(1); return &p }()), true, ""},
+		{"EmptySlice", RequiredFunc("T"), reflect.ValueOf([]int{}), false, ""},
+		{"NilMap", RequiredFunc("T"), reflect.ValueOf(map[string]int(nil)), false, ""},
+		{"ZeroTime", RequiredFunc("T"), reflect.ValueOf(time.Time{}), false, ""},
+		{"ZeroTimeInLoc", RequiredFunc("T"), reflect.ValueOf(time.Time{}.In(time.FixedZone("X", 3600))), false, ""},
+		{"Time", RequiredFunc("T"), reflect.ValueOf(time.Now()), true, ""},
+		{"Emptier", RequiredFunc("T"), reflect.ValueOf(testEmptier{"none"}), false, ""},
+		{"EmptierPtr", RequiredFunc("T"), reflect.ValueOf(&testPtrEmptier{"none"}), false, ""},
+		{"NotEmptier", RequiredFunc("T"), reflect.ValueOf(testEmptier{"a"}), true, ""},
+	} {
+		t.Run(s.name, s.test)
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	var nilIface interface{}
+	for _, tc := range []struct {
+		name  string
+		value reflect.Value
+		empty bool
+	}{
+		{"Invalid", reflect.ValueOf(nil), true},
+		{"Zero", reflect.ValueOf(0), true},
+		{"Int", reflect.ValueOf(1), false},
+		{"Float", reflect.ValueOf(0.5), false},
+		{"Bool", reflect.ValueOf(false), true},
+		{"EmptyString", reflect.ValueOf(""), true},
+		{"String", reflect.ValueOf("a"), false},
+		{"NilPtr", reflect.ValueOf((*int)(nil)), true},
+		{"PtrZero", reflect.ValueOf(intPtr(0)), true},
+		{"EmptySlice", reflect.ValueOf([]int{}), true},
+		{"Slice", reflect.ValueOf([]int{0}), false},
+		{"ZeroArray", reflect.ValueOf([2]int{}), true},
+		{"Array", reflect.ValueOf([2]int{0, 1}), false},
+		{"NilMap", reflect.ValueOf(map[string]int(nil)), true},
+		{"EmptyMap", reflect.ValueOf(map[string]int{}), true},
+		{"NilChan", reflect.ValueOf((chan int)(nil)), true},
+		{"NilFunc", reflect.ValueOf((func())(nil)), true},
+		{"NilInterface", reflect.ValueOf(&nilIface).Elem(), true},
+		{"ZeroStruct", reflect.ValueOf(struct{ A int }{}), true},
+		{"Struct", reflect.ValueOf(struct{ A int }{1}), false},
+		{"Emptier", reflect.ValueOf(testEmptier{}), true},
+		{"AddressableEmptier", reflect.ValueOf(&struct{ E testPtrEmptier }{testPtrEmptier{"none"}}).Elem().Field(0), true},
+		{"Unexported", reflect.ValueOf(struct{ e testEmptier }{testEmptier{"none"}}).Field(0), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if empty := isEmpty(tc.value); empty != tc.empty {
+				t.Fatalf("%s failed: expect [%v], but got [%v]\n", t.Name(), tc.empty, empty)
+			}
+		})
+	}
+}
+
+func TestValidator_Required(t *testing.T) {
+	type model struct {
+		Name     string     `validate:"required(T) minlength(3)"`
+		Nick     string     `validate:"omitempty(T) minlength(3)"`
+		Age      **int      `validate:"omitempty(T) min(18)"`
+		Birthday *time.Time `validate:"required(T,birthday required)"`
+		Tags     []string   `validate:"required(T) arr_minlength(2)"`
+	}
+	age, young := intPtr(20), intPtr(12)
+	now := time.Now()
+	for _, tc := range []struct {
+		name  string
+		m     *model
+		rules map[string][]string
+	}{
+		{"Empty", &model{}, map[string][]string{
+			"Name": {"required"}, "Birthday": {"required"}, "Tags": {"required"}}},
+		{"Invalid", &model{Name: "ab", Nick: "ab", Age: &young, Birthday: &now, Tags: []string{"a"}}, map[string][]string{
+			"Name": {"minlength"}, "Nick": {"minlength"}, "Age": {"min"}, "Tags": {"minsize"}}},
+		{"Valid", &model{Name: "abc", Age: &age, Birthday: &now, Tags: []string{"a", "b"}}, map[string][]string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(tc.m).Validate()
+			rules := make(map[string][]string)
+			for _, item := range r.Items {
+				for _, fe := range item.Errors {
+					rules[item.Path] = append(rules[item.Path], fe.Rule)
+				}
+			}
+			if !reflect.DeepEqual(rules, tc.rules) {
+				t.Fatalf("%s failed: expect %v, but got %v\n", t.Name(), tc.rules, rules)
+			}
+		})
+	}
+	r := New(&model{}).Validate()
+	if err := r.Err(); !errors.Is(err, ErrRequired) {
+		t.Fatalf("test failed: expect ErrRequired, but got [%v]\n", err)
+	}
+	if msg := r.Items[0].Errors[0].Message; msg != "Name is required" {
+		t.Fatalf("test failed: expect default msg, but got [%v]\n", msg)
+	}
+	if msg := r.Items[3].Errors[0].Message; msg != "birthday required" {
+		t.Fatalf("test failed: expect msg, but got [%v]\n", msg)
+	}
+}
+
+func TestValidator_RequiredNilPtr(t *testing.T) {
+	// every built-in rule accepts nil pointers, presence is checked by required
+	r := New(&struct {
+		Min    *int     `validate:"min(1) max(2) enum(1|2)"`
+		Length *string  `validate:"length(1) minlength(1) maxlength(1) regex(^a$)"`
+		Arr    *[]int   `validate:"arr_length(1) arr_minlength(1) arr_maxlength(1)"`
+		PtrPtr **string `validate:"minlength(1)"`
+	}{}).Validate()
+	if !r.Passed {
+		t.Fatalf("test failed: expect passed, but got [%v]\n", r.Err())
+	}
+}
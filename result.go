@@ -23,6 +23,7 @@ type Result struct {
 	Items     []*ResultItem
 	lang      []string
 	lm        map[string]int
+	err       error
 }
 
 func newResult(structPtr interface{}, items []*ResultItem, passed bool, lang []string) *Result {
@@ -40,33 +41,95 @@ func newResult(structPtr interface{}, items []*ResultItem, passed bool, lang []s
 	return rt
 }
 
+// NewResult return the result of validated items, it is passed when every item is passed.
+// It is used by the Validate methods written by validatorgen
+func NewResult(structPtr interface{}, items []*ResultItem) *Result {
+	passed := true
+	for _, item := range items {
+		if !item.Passed {
+			passed = false
+			break
+		}
+	}
+	return newResult(structPtr, items, passed, nil)
+}
+
 // ResultItem struct
 type ResultItem struct {
 	Field   *reflect.StructField
 	Passed  bool
-	Message string
+	Message string        // Message of the last failed rule, see Result.Messages for every failed rule
+	Path    string        // Path from the validated struct, e.g. Items[3].Sku or Meta["region"].Name
+	Errors  []*FieldError // Errors of every failed rule
 }
 
-// Messages return un-passed messages
-func (r *Result) Messages(lang ...string) string {
-	langPos := 0
-	if len(lang) > 0 {
-		if lang0 := lang[0]; len(lang0) > 0 {
-			if langPos0, ok := r.lm[lang0]; ok && langPos0 != -1 {
-				langPos = langPos0
-			}
-		}
+// Err return the failed rules as ValidationErrors, or nil when passed.
+// When the validation was stopped by its context, Err return the context error,
+// Var and ValidateMap return their malformed tags as TagErrors.
+func (r *Result) Err() error {
+	if r.err != nil {
+		return r.err
 	}
+	var errs ValidationErrors
+	for _, item := range r.Items {
+		errs = append(errs, item.Errors...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Messages return the messages of every failed rule of the un-passed items, without duplicates per item, or the item message
+// of an item without rule messages, in lang, a language or an Accept-Language header matched by MatchLang.
+// Messages are in the first language of Validator.Lang when lang is empty or matches no language,
+// and when a message has no translation for the language
+func (r *Result) Messages(lang ...string) string {
+	langPos := r.langPos(lang)
 	messages := make([]string, 0)
 	for _, item := range r.Items {
-		msg := item.Message
-		if !item.Passed && msg != "" {
-			msgS := strings.Split(msg, "|")
-			if langPos <= len(msgS) {
-				msg = msgS[langPos]
-			}
-			messages = append(messages, msg)
+		if item.Passed {
+			continue
 		}
+		var itemMessages []string
+		for _, fe := range item.Errors {
+			itemMessages = appendMessage(itemMessages, fe.Message, langPos)
+		}
+		if len(itemMessages) == 0 {
+			itemMessages = appendMessage(itemMessages, item.Message, langPos)
+		}
+		messages = append(messages, itemMessages...)
 	}
 	return strings.Join(messages, ",")
 }
+
+// langPos return the position of the language matching lang[0], 0 when lang is empty or matches no language
+func (r *Result) langPos(lang []string) int {
+	if len(lang) == 0 || lang[0] == "" {
+		return 0
+	}
+	langPos, _ := r.matchLang(lang[0])
+	return langPos
+}
+
+// localize return the translation of msg at langPos, or its first translation
+func localize(msg string, langPos int) string {
+	msgS := splitLangs(msg)
+	if langPos < len(msgS) {
+		return msgS[langPos]
+	}
+	return msgS[0]
+}
+
+// MatchLang return the language of Validator.Lang matching lang, a language or an Accept-Language header,
+// e.g. zh-CN for "zh-TW,en;q=0.5" with Lang("en", "zh-CN"). Languages match exactly first, then by BCP 47 matching,
+// it returns the first language and false when none matches
+func (r *Result) MatchLang(lang string) (string, bool) {
+	pos, ok := r.matchLang(lang)
+	for _, l := range r.lang {
+		if p, found := r.lm[l]; found && p == pos {
+			return l, ok
+		}
+	}
+	return "", false
+}
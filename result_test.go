@@ -12,15 +12,18 @@
 package validator
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 )
 
 func TestResult(t *testing.T) {
 	r := Result{nil, false, []*ResultItem{
-		{nil, false, "fail"},
-		{nil, false, "fail2"},
-		{nil, false, "fail3"},
-	}, nil, nil}
+		{nil, false, "fail", "", nil},
+		{nil, false, "fail2", "", nil},
+		{nil, false, "fail3", "", nil},
+	}, nil, nil, nil}
 	if p := r.Passed; p != false {
 		t.Fatalf("test failed: expect passed [false], but got [%v]\n", p)
 	}
@@ -43,6 +46,16 @@ func TestNewResult(t *testing.T) {
 	}
 }
 
+func TestNewResult_Passed(t *testing.T) {
+	items := []*ResultItem{{Passed: true}, {Passed: false, Message: "fail"}}
+	if r := NewResult(nil, items[:1]); !r.Passed || r.Err() != nil {
+		t.Fatalf("test failed: expect passed, but got [%v]\n", r.Passed)
+	}
+	if r := NewResult(nil, items); r.Passed || r.Messages() != "fail" {
+		t.Fatalf("test failed: expect un-passed, but got [%v] [%s]\n", r.Passed, r.Messages())
+	}
+}
+
 func TestResult_Messages(t *testing.T) {
 	zhCN := "zh-CN"
 	enUS := "en-US"
@@ -67,3 +80,87 @@ func TestResult_Messages(t *testing.T) {
 		t.Fatalf("test failed: expect [%s], but got [%s]\n", msgEnUS, msg)
 	}
 }
+
+func TestResult_MessagesRules(t *testing.T) {
+	r := New(&struct {
+		Name string `validate:"minlength(5,too short|太短) regex(^[a-z]+$,lowercase only|只能小写)"`
+		Code string `validate:"minlength(3) maxlength(1) msg(bad code)"`
+	}{"AB", "AB"}).Lang("en", "zh-CN").Validate()
+	if msg := r.Messages(); msg != "too short,lowercase only,bad code" {
+		t.Fatalf("test failed: expect [too short,lowercase only,bad code], but got [%s]\n", msg)
+	}
+	if msg := r.Messages("zh-CN"); msg != "太短,只能小写,bad code" {
+		t.Fatalf("test failed: expect [太短,只能小写,bad code], but got [%s]\n", msg)
+	}
+}
+
+func TestResult_MessagesLang(t *testing.T) {
+	items := []*ResultItem{{Message: "错误|Error|Erreur"}, {Message: "只有中文"}, {Message: "太小|small"}}
+	rt := newResult(struct{}{}, items, false, parseLangs([]string{"zh-CN", "en-US", "fr"}))
+	for _, tc := range []struct {
+		name, lang, expect, matched string
+		ok                          bool
+	}{
+		{"Default", "", "错误,只有中文,太小", "", false},
+		{"Exact", "fr", "Erreur,只有中文,太小", "fr", true},
+		{"Canonical", "en_us", "Error,只有中文,small", "en-US", true},
+		{"Region", "en-GB", "Error,只有中文,small", "en-US", true},
+		{"Base", "zh", "错误,只有中文,太小", "zh-CN", true},
+		{"AcceptLanguage", "de-DE,fr;q=0.9,en;q=0.8", "Erreur,只有中文,太小", "fr", true},
+		{"Unknown", "ja", "错误,只有中文,太小", "zh-CN", false},
+		{"Malformed", "!!", "错误,只有中文,太小", "zh-CN", false},
+		{"LongHeader", "fr," + strings.Repeat("de;q=0.5,", 1000), "Erreur,只有中文,太小", "fr", true},
+		{"LongTag", strings.Repeat("e", 1000), "错误,只有中文,太小", "zh-CN", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if msg := rt.Messages(tc.lang); msg != tc.expect {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.expect, msg)
+			}
+			if tc.lang == "" {
+				return
+			}
+			if lang, ok := rt.MatchLang(tc.lang); lang != tc.matched || ok != tc.ok {
+				t.Fatalf("%s failed: expect [%s %v], but got [%s %v]\n", t.Name(), tc.matched, tc.ok, lang, ok)
+			}
+		})
+	}
+	if lang, ok := newResult(struct{}{}, items, false, nil).MatchLang("en"); lang != "" || ok {
+		t.Fatalf("test failed: expect no language, but got [%s %v]\n", lang, ok)
+	}
+}
+
+func TestResult_Err(t *testing.T) {
+	Custom("resultErr", func(value reflect.Value) (bool, string) { return false, "custom" })
+	r := New(&struct {
+		Age   int    `validate:"min(18,young) max(10)"`
+		Name  string `validate:"minlength(1) custom(resultErr) msg(name)"`
+		Email string `validate:"regex(^.+@.+$)"`
+		Items []testNestedItem
+	}{Age: 12, Email: "a@b", Items: []testNestedItem{{}}}).Validate()
+	err := r.Err()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("test failed: expect ValidationErrors, but got [%T]\n", err)
+	}
+	expect := ValidationErrors{
+		{"Age", "min", "18", 12, "young"},
+		{"Age", "max", "10", 12, "Age must be at most 10"},
+		{"Name", "minlength", "1", "", "name"},
+		{"Name", "custom:resultErr", "", "", "custom"},
+		{"Items[0].Sku", "minlength", "1", "", "sku"},
+	}
+	if !reflect.DeepEqual(errs, expect) {
+		t.Fatalf("test failed: expect [%v], but got [%v]\n", expect, errs)
+	}
+	for _, target := range []error{ErrMin, ErrMax, ErrMinLength, ErrCustom} {
+		if !errors.Is(err, target) {
+			t.Fatalf("test failed: expect is [%v]\n", target)
+		}
+	}
+	if errors.Is(err, ErrRegex) {
+		t.Fatalf("test failed: expect is not [%v]\n", ErrRegex)
+	}
+	if err := New(&struct{}{}).Validate().Err(); err != nil {
+		t.Fatalf("test failed: expect nil, but got [%v]\n", err)
+	}
+}
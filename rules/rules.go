@@ -0,0 +1,120 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rules builds the rules of the validator in code, for validator.For.
+//
+// Every rule is the rule of the same name of the tag syntax, e.g. rules.Min(18) is min(18):
+//
+//	validator.For(&User{}).
+//		Field("Email", rules.Required(), rules.MaxLength(255), rules.Regex(emailRe)).
+//		Field("Age", rules.Min(18).Msg("too young"))
+package rules
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-the-way/validator"
+)
+
+// Required value must be not empty
+func Required() validator.Rule { return validator.NewRule("required", "true") }
+
+// OmitEmpty skips the other rules of an empty value
+func OmitEmpty() validator.Rule { return validator.NewRule("omitempty", "true") }
+
+// Valid pointer must be not nil
+func Valid() validator.Rule { return validator.NewRule("valid", "true") }
+
+// Min every value must be >= min
+func Min(min float64) validator.Rule { return validator.NewRule("min", formatFloat(min)) }
+
+// Max every value must be <= max
+func Max(max float64) validator.Rule { return validator.NewRule("max", formatFloat(max)) }
+
+// Length every string must have a len == length
+func Length(length int) validator.Rule { return validator.NewRule("length", strconv.Itoa(length)) }
+
+// MinLength every string must have a len >= minLength
+func MinLength(minLength int) validator.Rule {
+	return validator.NewRule("minlength", strconv.Itoa(minLength))
+}
+
+// MaxLength every string must have a len <= maxLength
+func MaxLength(maxLength int) validator.Rule {
+	return validator.NewRule("maxlength", strconv.Itoa(maxLength))
+}
+
+// Size array, slice or map must have a len == size
+func Size(size int) validator.Rule { return validator.NewRule("size", strconv.Itoa(size)) }
+
+// MinSize array, slice or map must have a len >= minSize
+func MinSize(minSize int) validator.Rule { return validator.NewRule("minsize", strconv.Itoa(minSize)) }
+
+// MaxSize array, slice or map must have a len <= maxSize
+func MaxSize(maxSize int) validator.Rule { return validator.NewRule("maxsize", strconv.Itoa(maxSize)) }
+
+// Enum every value must be one of options
+func Enum(options ...string) validator.Rule {
+	return validator.RuleOf("enum", strings.Join(options, "|"), validator.EnumOptionsFunc(options...))
+}
+
+// Regex every string must match re
+func Regex(re *regexp.Regexp) validator.Rule {
+	return validator.RuleOf("regex", re.String(), validator.RegexpFunc(re))
+}
+
+// EqField value must be == the field reached by path
+func EqField(path string) validator.Rule { return validator.NewRule("eqfield", path) }
+
+// NeField value must be != the field reached by path
+func NeField(path string) validator.Rule { return validator.NewRule("nefield", path) }
+
+// GtField value must be > the field reached by path
+func GtField(path string) validator.Rule { return validator.NewRule("gtfield", path) }
+
+// LtField value must be < the field reached by path
+func LtField(path string) validator.Rule { return validator.NewRule("ltfield", path) }
+
+// RequiredIf value must be not empty when the field reached by path equals value, the field is skipped otherwise
+func RequiredIf(path, value string) validator.Rule {
+	return validator.RuleOf("required_if", path+" "+value, validator.RequiredIfValueFunc(path, value))
+}
+
+// RequiredUnless value must be not empty unless the field reached by path equals value, the field is skipped otherwise
+func RequiredUnless(path, value string) validator.Rule {
+	return validator.RuleOf("required_unless", path+" "+value, validator.RequiredUnlessValueFunc(path, value))
+}
+
+// RequiredWith value must be not empty when any of the fields is present, the field is skipped otherwise
+func RequiredWith(paths ...string) validator.Rule {
+	return validator.NewRule("required_with", strings.Join(paths, " "))
+}
+
+// RequiredWithout value must be not empty when any of the fields is missing, the field is skipped otherwise
+func RequiredWithout(paths ...string) validator.Rule {
+	return validator.NewRule("required_without", strings.Join(paths, " "))
+}
+
+// Func value must pass fn, the rule is named custom:name like the custom validators
+func Func(name string, fn func(value reflect.Value) (bool, string)) validator.Rule {
+	return validator.RuleOf("custom:"+name, "", vFunc(fn))
+}
+
+// vFunc adapts a func to validator.VFunc
+type vFunc func(value reflect.Value) (bool, string)
+
+// Valid method
+func (f vFunc) Valid(value reflect.Value) (bool, string) { return f(value) }
+
+func formatFloat(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
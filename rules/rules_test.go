@@ -0,0 +1,126 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-the-way/validator"
+)
+
+type testUser struct {
+	Name     string
+	Email    string
+	Age      int
+	Tags     []string
+	Role     string
+	Password string
+	Confirm  string
+	Start    time.Time
+	End      time.Time
+	Company  string
+	Phone    string
+	Mobile   string
+	Address  *struct{}
+	Code     string `validate:"length(3)"`
+}
+
+func TestRules(t *testing.T) {
+	validator.For(&testUser{}).
+		Field("Name", Required(), MinLength(2), MaxLength(5)).
+		Field("Email", OmitEmpty(), Regex(regexp.MustCompile("^[^@]+@[^@]+$")).Msg("bad email")).
+		Field("Age", Min(18), Max(130)).
+		Field("Tags", MinSize(1), MaxSize(3), Length(1)).
+		Field("Role", Enum("admin", "user")).
+		Field("Confirm", EqField("Password")).
+		Field("Password", NeField("Name")).
+		Field("End", GtField("Start")).
+		Field("Start", LtField("End")).
+		Field("Company", RequiredIf("Role", "admin")).
+		Field("Phone", RequiredWithout("Mobile")).
+		Field("Mobile", RequiredWith("Phone"), RequiredUnless("Role", "user")).
+		Field("Address", Valid()).
+		Field("Code", Size(3), Func("upper", func(value reflect.Value) (bool, string) {
+			return value.String() == "ABC", "upper"
+		}))
+	now := time.Now()
+	valid := testUser{"john", "", 18, []string{"a"}, "user", "p", "p", now, now.Add(time.Hour), "", "1", "2", &struct{}{}, "ABC"}
+	if err := validator.New(&valid).Err(); err != nil {
+		t.Fatalf("test failed: expect nil err, but got [%v]\n", err)
+	}
+	if r := validator.New(&valid).Validate(); !r.Passed {
+		t.Fatalf("test failed: expect passed, but got [%v]\n", r.Err())
+	}
+	invalid := testUser{"j", "a", 12, []string{}, "admin", "j", "p", now, now, "", "", "", nil, "abc"}
+	r := validator.New(&invalid).Validate()
+	errs := make(map[string][]string)
+	for _, item := range r.Items {
+		for _, fe := range item.Errors {
+			errs[fe.Path] = append(errs[fe.Path], fe.Rule)
+		}
+	}
+	expect := map[string][]string{
+		"Name": {"minlength"}, "Email": {"regex"}, "Age": {"min"}, "Tags": {"minsize"}, "Confirm": {"eqfield"},
+		"Password": {"nefield"}, "End": {"gtfield"}, "Start": {"ltfield"}, "Company": {"required_if"},
+		"Phone": {"required_without"}, "Mobile": {"required_unless"}, "Address": {"valid"}, "Code": {"custom:upper"},
+	}
+	if !reflect.DeepEqual(errs, expect) {
+		t.Fatalf("test failed: expect %v, but got %v\n", expect, errs)
+	}
+	if msg := r.Items[1].Message; msg != "bad email" {
+		t.Fatalf("test failed: msg expect [bad email], but got [%v]\n", msg)
+	}
+}
+
+type testOrder struct {
+	City   string
+	Status string
+	Note   string
+	Reason string
+}
+
+func TestRules_Args(t *testing.T) {
+	validator.For(&testOrder{}).
+		Field("City", Enum("Paris, France", "Lyon|France")).
+		Field("Note", RequiredIf("Status", "in progress")).
+		Field("Reason", RequiredUnless("Status", "in progress").Msg("reason required"))
+	for _, tc := range []struct {
+		name   string
+		order  testOrder
+		failed []string
+	}{
+		{"Comma", testOrder{City: "Paris, France", Status: "in progress", Note: "a"}, nil},
+		{"Pipe", testOrder{City: "Lyon|France", Status: "done", Reason: "a"}, nil},
+		{"Split", testOrder{City: "Paris", Status: "done", Reason: "a"}, []string{"City"}},
+		{"SpaceMet", testOrder{City: "Paris, France", Status: "in progress"}, []string{"Note"}},
+		{"SpaceUnmet", testOrder{City: "Paris, France", Status: "in"}, []string{"Reason"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := validator.New(&tc.order)
+			if err := v.Err(); err != nil {
+				t.Fatalf("%s failed: expect nil err, but got [%v]\n", t.Name(), err)
+			}
+			var failed []string
+			for _, item := range v.Validate().Items {
+				if !item.Passed {
+					failed = append(failed, item.Path)
+				}
+			}
+			if !reflect.DeepEqual(failed, tc.failed) {
+				t.Fatalf("%s failed: expect failed %v, but got %v\n", t.Name(), tc.failed, failed)
+			}
+		})
+	}
+}
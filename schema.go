@@ -0,0 +1,202 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// schemaCache caches the compiled schema of every validated struct type
+var schemaCache sync.Map
+
+// cachedSchema is a compiled schema with its malformed tags
+type cachedSchema struct {
+	schema *structSchema
+	err    error
+}
+
+// loadSchema return the compiled schema of typ, compiling it on first use
+func loadSchema(typ reflect.Type) (*structSchema, error) {
+	if cs, ok := schemaCache.Load(typ); ok {
+		return cs.(*cachedSchema).schema, cs.(*cachedSchema).err
+	}
+	c := &schemaCompiler{root: typ, schemas: make(map[reflect.Type]*structSchema)}
+	cs := &cachedSchema{schema: c.compile(typ)}
+	if len(c.errs) > 0 {
+		cs.err = c.errs
+	}
+	actual, _ := schemaCache.LoadOrStore(typ, cs)
+	return actual.(*cachedSchema).schema, actual.(*cachedSchema).err
+}
+
+// structSchema holds the validate fields of a struct type
+type structSchema struct {
+	typ      reflect.Type
+	fields   []*fieldSchema
+	building bool
+}
+
+// fieldSchema holds the rules of a struct field
+type fieldSchema struct {
+	field reflect.StructField
+	elemSchema
+	nested *structSchema // nested is the struct reached through the field, if any
+}
+
+// elemSchema holds the rules of a field or of the elements of a field
+type elemSchema struct {
+	item   *Item       // item is nil when there is no validate tag
+	rules  []*rule     // rules is nil when the tag is malformed
+	keys   *elemSchema // keys holds the rules of every map key, if any
+	values *elemSchema // values holds the rules of every map value, if any
+	dive   *elemSchema // dive holds the rules of every slice or array element, if any
+}
+
+// schemaCompiler compiles struct types, collecting malformed tags
+type schemaCompiler struct {
+	root    reflect.Type
+	schemas map[reflect.Type]*structSchema
+	errs    TagErrors
+}
+
+func (c *schemaCompiler) compile(typ reflect.Type) *structSchema {
+	if s, ok := c.schemas[typ]; ok {
+		return s
+	}
+	s := &structSchema{typ: typ, building: true}
+	c.schemas[typ] = s
+	built := fieldRules(typ)
+	names := make([]string, 0, len(built))
+	for name := range built {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if field, ok := typ.FieldByName(name); !ok || len(field.Index) != 1 {
+			c.errs = append(c.errs, &TagError{typ, name, built[name][0].name, built[name][0].str, errors.New("unknown field")})
+		}
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "-" && built[field.Name] == nil {
+			continue
+		}
+		item, err := newItem(tag)
+		if item == nil && err == nil && built[field.Name] != nil {
+			item = new(Item)
+		}
+		fs := &fieldSchema{field: field, elemSchema: elemSchema{item: item}}
+		nested := true
+		var errs TagErrors
+		if err != nil {
+			err.Struct, err.Field = typ, field.Name
+			errs = append(errs, err)
+		} else if fs.item != nil {
+			errs = c.compileElem(&fs.elemSchema, typ, field)
+			errs = append(errs, c.compileBuilt(&fs.elemSchema, built[field.Name], typ, field)...)
+			if fs.item.Nested != "" {
+				var err error
+				if nested, err = strconv.ParseBool(fs.item.Nested); err != nil {
+					errs = append(errs, &TagError{typ, field.Name, "nested", fs.item.Nested, err})
+				}
+			}
+		}
+		if len(errs) > 0 {
+			c.errs = append(c.errs, errs...)
+			fs.elemSchema = elemSchema{item: fs.item}
+		}
+		if nested {
+			if elem := structElem(field.Type); elem != nil {
+				if ns := c.compile(elem); ns.building || len(ns.fields) > 0 {
+					fs.nested = ns
+				}
+			}
+		}
+		if fs.item != nil || fs.nested != nil {
+			s.fields = append(s.fields, fs)
+		}
+	}
+	s.building = false
+	return s
+}
+
+// compileElem compiles the rules of es and of its keys, values and dive elements, field.Type is the type of the value,
+// rules are not type checked when it is nil. The rule names of malformed elements are prefixed by keys., values. or dive.
+func (c *schemaCompiler) compileElem(es *elemSchema, structType reflect.Type, field reflect.StructField) TagErrors {
+	rules, errs := es.item.compile(c.root, structType, field)
+	es.rules = rules
+	typ := field.Type
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	for _, e := range []struct {
+		name   string
+		tag    string
+		schema **elemSchema
+		kinds  string
+	}{
+		{"keys", es.item.Keys, &es.keys, "map"},
+		{"values", es.item.Values, &es.values, "map"},
+		{"dive", es.item.Dive, &es.dive, "slice array"},
+	} {
+		if e.tag == "" {
+			continue
+		}
+		if typ != nil && !strings.Contains(e.kinds, typ.Kind().String()) {
+			errs = append(errs, &TagError{structType, field.Name, e.name, e.tag, fmt.Errorf("%s requires a %s field", e.name, strings.Replace(e.kinds, " ", " or ", 1))})
+			continue
+		}
+		item, err := newItem(e.tag)
+		var elemErrs TagErrors
+		if err != nil {
+			err.Struct, err.Field = structType, field.Name
+			elemErrs = TagErrors{err}
+		} else if item != nil {
+			elemField := field
+			switch {
+			case typ == nil:
+			case e.name == "keys":
+				elemField.Type = typ.Key()
+			default:
+				elemField.Type = typ.Elem()
+			}
+			*e.schema = &elemSchema{item: item}
+			elemErrs = c.compileElem(*e.schema, structType, elemField)
+		}
+		for _, elemErr := range elemErrs {
+			elemErr.Rule = e.name + "." + elemErr.Rule
+		}
+		errs = append(errs, elemErrs...)
+	}
+	return errs
+}
+
+// structElem return the struct type reached through pointers and collections of typ, or nil
+func structElem(typ reflect.Type) reflect.Type {
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		case reflect.Struct:
+			return typ
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// tagCache caches the rules of every tag validated outside of a struct
+var tagCache sync.Map
+
+// tagKey is a tag with the type of the values it validates
+type tagKey struct {
+	typ reflect.Type
+	tag string
+}
+
+// cachedTag is a compiled tag with its malformed rules
+type cachedTag struct {
+	schema *elemSchema
+	errs   TagErrors
+}
+
+// ruleAliases maps the alias names of rules to their rule
+var ruleAliases = map[string]string{
+	"arr_length":    "size",
+	"arr_minlength": "minsize",
+	"arr_maxlength": "maxsize",
+}
+
+// itemAliases maps a rule name or alias to the index of its Item field
+var itemAliases = func() map[string]int {
+	typ := reflect.TypeOf(Item{})
+	aliases := make(map[string]int, typ.NumField()+len(ruleAliases))
+	for i := 0; i < typ.NumField(); i++ {
+		aliases[typ.Field(i).Tag.Get("alias")] = i
+	}
+	for alias, name := range ruleAliases {
+		aliases[alias] = aliases[name]
+	}
+	return aliases
+}()
+
+// TagRule is a rule of a validate tag, e.g. min(10,fail) is named min with the value 10,fail
+type TagRule struct {
+	Name   string
+	Value  string // Value is the text between the parentheses, without surrounding spaces
+	Param  string // Param is the param of the value, unquoted
+	Msg    string // Msg is the message of the value, unquoted
+	Column int    // Column is the 1-based byte column of the rule in the tag
+}
+
+// parseTag splits a validate tag into its rules, see the package documentation for the grammar.
+// Text between rules is ignored
+func parseTag(tag string) ([]TagRule, *TagError) {
+	var rules []TagRule
+	l := &lexer{src: tag}
+	for tok := l.next(); tok.kind != tokEOF; tok = l.next() {
+		if tok.kind != tokName || l.pos == len(tag) || tag[l.pos] != '(' {
+			continue
+		}
+		name, open := tag[tok.pos:tok.end], l.next().pos
+		param, msg, err := l.value(open)
+		if err != nil {
+			return rules, &TagError{Rule: name, Text: tag[open+1:], Err: err}
+		}
+		rules = append(rules, TagRule{name, strings.TrimSpace(tag[open+1 : l.pos-1]), param, msg, tok.pos + 1})
+	}
+	return rules, nil
+}
+
+// ParseRules splits a validate tag into its rules with the grammar of Item, unknown rule names included,
+// a malformed tag is returned as a *TagError with the rules before it
+func ParseRules(tag string) ([]TagRule, error) {
+	rules, err := parseTag(tag)
+	if err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
+
+// IsRuleName reports whether name is the name or alias of a rule of Item
+func IsRuleName(name string) bool {
+	_, ok := itemAliases[name]
+	return ok
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// newItem return the Item of a validate tag, or nil when the tag has no rule,
+// unknown rules are ignored, a malformed tag is returned as a *TagError without its struct and field
+func newItem(tag string) (*Item, *TagError) {
+	rules, err := parseTag(tag)
+	if len(rules) == 0 && err == nil {
+		return nil, nil
+	}
+	item := new(Item)
+	value := reflect.ValueOf(item).Elem()
+	for _, r := range rules {
+		if i, ok := itemAliases[r.Name]; ok && r.Value != "" {
+			v := r.Value
+			if r.Name == "msg" && strings.HasPrefix(v, "'") {
+				// the whole value is the message, unquoted when quoted
+				v = r.Param
+			}
+			value.Field(i).SetString(v)
+		}
+	}
+	return item, err
+}
+
+// ParseTag return the Item of a validate tag, or nil when the tag has no rule, like the tags of struct fields,
+// a malformed tag is returned as a *TagError without its struct and field. It is used by tools reading tags
+// outside of a running program, like validatorgen
+func ParseTag(tag string) (*Item, error) {
+	item, err := newItem(tag)
+	if err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// loadTag return the compiled rules of a tag validating values of type typ, compiling them on first use,
+// rules are not type checked when typ is nil. The rules of a malformed tag are empty
+func loadTag(typ reflect.Type, tag string) (*elemSchema, TagErrors) {
+	key := tagKey{typ, tag}
+	if ct, ok := tagCache.Load(key); ok {
+		return ct.(*cachedTag).schema, ct.(*cachedTag).errs
+	}
+	item, err := newItem(tag)
+	if item == nil {
+		item = new(Item)
+	}
+	ct := &cachedTag{schema: &elemSchema{item: item}}
+	if err != nil {
+		ct.errs = TagErrors{err}
+	} else {
+		ct.errs = new(schemaCompiler).compileElem(ct.schema, nil, reflect.StructField{Type: typ})
+	}
+	if len(ct.errs) > 0 {
+		ct.schema = &elemSchema{item: item}
+	}
+	actual, _ := tagCache.LoadOrStore(key, ct)
+	return actual.(*cachedTag).schema, actual.(*cachedTag).errs
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagError describes a malformed rule in a validate tag
+type TagError struct {
+	Struct reflect.Type // Struct declares the field
+	Field  string       // Field is the field name
+	Rule   string       // Rule is the rule name, e.g. min
+	Text   string       // Text is the offending rule text
+	Err    error        // Err is the underlying parse error
+}
+
+// Error method, the struct is left out when nil and the field when empty, e.g. for the tags of Var and ValidateMap
+func (e *TagError) Error() string {
+	var names []string
+	if e.Struct != nil {
+		names = append(names, e.Struct.String())
+	}
+	if e.Field != "" {
+		names = append(names, e.Field)
+	}
+	prefix := "validator: "
+	if len(names) > 0 {
+		prefix += strings.Join(names, ".") + ": "
+	}
+	return fmt.Sprintf("%sinvalid %s(%s): %v", prefix, e.Rule, e.Text, e.Err)
+}
+
+// Unwrap method
+func (e *TagError) Unwrap() error { return e.Err }
+
+// TagErrors holds every malformed rule of a struct
+type TagErrors []*TagError
+
+// Error method
+func (es TagErrors) Error() string {
+	messages := make([]string, len(es))
+	for i, e := range es {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrTag
+func (e *TagError) Is(target error) bool { return target == ErrTag }
+
+// Is reports whether target is ErrTag
+func (es TagErrors) Is(target error) bool { return target == ErrTag && len(es) > 0 }
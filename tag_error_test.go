@@ -0,0 +1,65 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestTagError(t *testing.T) {
+	_, err := strconv.ParseFloat("A", 64)
+	e := &TagError{reflect.TypeOf(struct{ Age int }{}), "Age", "min", "A", err}
+	expect := `validator: struct { Age int }.Age: invalid min(A): strconv.ParseFloat: parsing "A": invalid syntax`
+	if msg := e.Error(); msg != expect {
+		t.Fatalf("test failed: expect [%s], but got [%s]\n", expect, msg)
+	}
+	if !errors.Is(e, strconv.ErrSyntax) {
+		t.Fatalf("test failed: expect [%v] is [%v]\n", e, strconv.ErrSyntax)
+	}
+}
+
+func TestTagErrorWithoutStruct(t *testing.T) {
+	for _, tc := range []struct {
+		e      *TagError
+		expect string
+	}{
+		{&TagError{nil, "a", "min", "A", errors.New("fail")}, "validator: a: invalid min(A): fail"},
+		{&TagError{nil, "", "min", "A", errors.New("fail")}, "validator: invalid min(A): fail"},
+		{&TagError{reflect.TypeOf(struct{}{}), "", "min", "A", errors.New("fail")}, "validator: struct {}: invalid min(A): fail"},
+	} {
+		if msg := tc.e.Error(); msg != tc.expect {
+			t.Fatalf("test failed: expect [%s], but got [%s]\n", tc.expect, msg)
+		}
+	}
+	r := ValidateMap(map[string]interface{}{"a": 1}, map[string]string{"a": "min(A)"})
+	if err := r.Err(); err == nil || err.Error() != `validator: a: invalid min(A): strconv.ParseFloat: parsing "A": invalid syntax` {
+		t.Fatalf("test failed: unexpected err [%v]\n", err)
+	}
+	_, err := ParseRules("min(1")
+	if err == nil || err.Error() != "validator: invalid min(1): column 4: unbalanced parentheses" {
+		t.Fatalf("test failed: unexpected err [%v]\n", err)
+	}
+}
+
+func TestTagErrors(t *testing.T) {
+	es := TagErrors{
+		{reflect.TypeOf(struct{}{}), "A", "min", "A", errors.New("fail")},
+		{reflect.TypeOf(struct{}{}), "B", "max", "B", errors.New("fail2")},
+	}
+	expect := "validator: struct {}.A: invalid min(A): fail; validator: struct {}.B: invalid max(B): fail2"
+	if msg := es.Error(); msg != expect {
+		t.Fatalf("test failed: expect [%s], but got [%s]\n", expect, msg)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		tag    string
+		rules  []TagRule
+		column int // column of the syntax error, if any
+	}{
+		{"Empty", "", nil, 0},
+		{"Rule", "min(1)", []TagRule{{"min", "1", "1", "", 1}}, 0},
+		{"Rules", "min(1) max(2,fail)", []TagRule{{"min", "1", "1", "", 1}, {"max", "2,fail", "2", "fail", 8}}, 0},
+		{"Trim", "min( 1 )", []TagRule{{"min", "1", "1", "", 1}}, 0},
+		{"Text", "required min(1); x", []TagRule{{"min", "1", "1", "", 10}}, 0},
+		{"Nested", "keys(regex(^[a-z]+$)) values(min(0))",
+			[]TagRule{{"keys", "regex(^[a-z]+$)", "regex(^[a-z]+$)", "", 1}, {"values", "min(0)", "min(0)", "", 23}}, 0},
+		{"NestedGroup", "regex(^(a|b)$)", []TagRule{{"regex", "^(a|b)$", "^(a|b)$", "", 1}}, 0},
+		{"NestedComma", "regex(^(a,b)$,fail)", []TagRule{{"regex", "^(a,b)$,fail", "^(a,b)$", "fail", 1}}, 0},
+		{"MsgComma", "min(1,too small, really)", []TagRule{{"min", "1,too small, really", "1", "too small, really", 1}}, 0},
+		{"LegacySep", "min(1[,]fail)", []TagRule{{"min", "1[,]fail", "1", "fail", 1}}, 0},
+		{"LegacySepComma", `regex(^\d{1,3}$[,]bad code)`,
+			[]TagRule{{"regex", `^\d{1,3}$[,]bad code`, `^\d{1,3}$`, "bad code", 1}}, 0},
+		{"LegacySepMsgComma", "min(1[,]too small, really)", []TagRule{{"min", "1[,]too small, really", "1", "too small, really", 1}}, 0},
+		{"Apostrophe", "msg(can't be empty)", []TagRule{{"msg", "can't be empty", "can't be empty", "", 1}}, 0},
+		{"Quoted", `regex('^\d{1,3}$', 'bad, (very) bad')`,
+			[]TagRule{{"regex", `'^\d{1,3}$', 'bad, (very) bad'`, `^\d{1,3}$`, "bad, (very) bad", 1}}, 0},
+		{"Escaped", `msg('it\'s \\ ok')`, []TagRule{{"msg", `'it\'s \\ ok'`, `it's \ ok`, "", 1}}, 0},
+		{"QuotedNested", "dive(regex('a)b'))", []TagRule{{"dive", "regex('a)b')", "regex('a)b')", "", 1}}, 0},
+		{"Unbalanced", "min(1) keys(regex(^a)", []TagRule{{"min", "1", "1", "", 1}}, 12},
+		{"Unterminated", "min(1) regex('^a)", []TagRule{{"min", "1", "1", "", 1}}, 14},
+		{"AfterQuote", "regex('^a'b)", nil, 11},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := parseTag(tc.tag)
+			if !reflect.DeepEqual(rules, tc.rules) {
+				t.Fatalf("%s failed: rules expect %v, but got %v\n", t.Name(), tc.rules, rules)
+			}
+			column := 0
+			if err != nil {
+				var se *SyntaxError
+				if !errors.As(err, &se) {
+					t.Fatalf("%s failed: expect a *SyntaxError, but got [%v]\n", t.Name(), err)
+				}
+				column = se.Column
+			}
+			if column != tc.column {
+				t.Fatalf("%s failed: column expect [%d], but got [%d] [%v]\n", t.Name(), tc.column, column, err)
+			}
+		})
+	}
+}
+
+func TestSplitValue(t *testing.T) {
+	for _, tc := range []struct {
+		value, param, msg string
+		err               bool
+	}{
+		{"10", "10", "", false},
+		{"10,too small", "10", "too small", false},
+		{"'a,b','c)'", "a,b", "c)", false},
+		{"a{1,3}[,]bad", "a{1,3}", "bad", false},
+		{"'a[,]b',c", "a[,]b", "c", false},
+		{"a)b", "", "", true},
+		{"'a", "", "", true},
+	} {
+		param, msg, err := SplitValue(tc.value)
+		if param != tc.param || msg != tc.msg || (err != nil) != tc.err {
+			t.Fatalf("test failed: SplitValue(%s) got [%s] [%s] [%v]\n", tc.value, param, msg, err)
+		}
+	}
+}
+
+func TestLegacySepRegex(t *testing.T) {
+	tag := `regex(^\d{1,3}$[,]bad code)`
+	if r := Var("123", tag); !r.Passed {
+		t.Fatalf("test failed: expect passed, but got [%s]\n", r.Messages())
+	}
+	if r := Var("1234", tag); r.Passed || r.Messages() != "bad code" {
+		t.Fatalf("test failed: got [%v] [%s]\n", r.Passed, r.Messages())
+	}
+}
+
+func TestQuotedTag(t *testing.T) {
+	tag := `regex('^\d{1,3}$','1 to 3 digits, no more') msg('bad (digits)')`
+	if r := Var("123", tag); !r.Passed {
+		t.Fatalf("test failed: expect passed, but got [%s]\n", r.Messages())
+	}
+	r := Var("1234", tag)
+	if r.Passed || r.Messages() != "1 to 3 digits, no more" || r.Items[0].Errors[0].Param != `^\d{1,3}$` {
+		t.Fatalf("test failed: got [%v] [%s]\n", r.Passed, r.Messages())
+	}
+	if r := Var("", `required(T) msg('bad (digits)')`); r.Messages() != "bad (digits)" {
+		t.Fatalf("test failed: got [%s]\n", r.Messages())
+	}
+	err := Var("1", "min('1)").Err()
+	if err == nil || !strings.Contains(err.Error(), "column 5: unterminated quoted argument") {
+		t.Fatalf("test failed: expect the column, but got [%v]\n", err)
+	}
+}
+
+func TestParseTag_Item(t *testing.T) {
+	if item, err := ParseTag("min(1) msg(fail)"); err != nil || *item != (Item{Min: "1", Msg: "fail"}) {
+		t.Fatalf("test failed: got [%v] [%v]\n", item, err)
+	}
+	if item, err := ParseTag(""); item != nil || err != nil {
+		t.Fatalf("test failed: expect nil item and error, but got [%v] [%v]\n", item, err)
+	}
+	var tagErr *TagError
+	if _, err := ParseTag("min(1"); !errors.As(err, &tagErr) || tagErr.Rule != "min" {
+		t.Fatalf("test failed: expect a *TagError, but got [%v]\n", err)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("unknown(1) min(2)")
+	if err != nil || !reflect.DeepEqual(rules, []TagRule{{"unknown", "1", "1", "", 1}, {"min", "2", "2", "", 12}}) {
+		t.Fatalf("test failed: got %v [%v]\n", rules, err)
+	}
+	if _, err := ParseRules("min(2"); err == nil {
+		t.Fatal("test failed: expect error")
+	}
+	for name, known := range map[string]bool{"min": true, "arr_length": true, "dive": true, "msg": true, "unknown": false} {
+		if IsRuleName(name) != known {
+			t.Fatalf("test failed: IsRuleName(%s) expect [%v]\n", name, known)
+		}
+	}
+}
+
+func TestNewItem(t *testing.T) {
+	if item, err := newItem("unknown(1)"); item == nil || err != nil || *item != (Item{}) {
+		t.Fatalf("test failed: expect empty item, but got [%v] [%v]\n", item, err)
+	}
+	if item, err := newItem("x"); item != nil || err != nil {
+		t.Fatalf("test failed: expect nil item, but got [%v] [%v]\n", item, err)
+	}
+	item, err := newItem("min(1) msg(fail) keys(minlength(1))")
+	if err != nil || *item != (Item{Min: "1", Msg: "fail", Keys: "minlength(1)"}) {
+		t.Fatalf("test failed: got [%v] [%v]\n", item, err)
+	}
+	item, err = newItem("arr_length(1) arr_minlength(2) arr_maxlength(3)")
+	if err != nil || *item != (Item{ArrLength: "1", ArrMinLength: "2", ArrMaxLength: "3"}) {
+		t.Fatalf("test failed: expect aliases, but got [%v] [%v]\n", item, err)
+	}
+	if _, err = newItem("min(1"); err == nil || !errors.Is(err, errUnbalanced) {
+		t.Fatalf("test failed: expect unbalanced err, but got [%v]\n", err)
+	}
+}
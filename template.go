@@ -0,0 +1,160 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ruleParams names the param of a rule in message templates, e.g. {min} for min(10)
+var ruleParams = map[string]string{
+	"min":       "min",
+	"max":       "max",
+	"length":    "len",
+	"size":      "len",
+	"minlength": "min",
+	"minsize":   "min",
+	"maxlength": "max",
+	"maxsize":   "max",
+	"enum":      "enum",
+	"regex":     "regex",
+	"eqfield":   "other",
+	"nefield":   "other",
+	"gtfield":   "other",
+	"ltfield":   "other",
+}
+
+// templateParamer is implemented by VFuncs giving more placeholders to message templates
+type templateParamer interface {
+	templateParams() map[string]string
+}
+
+// FormatMessage fills the placeholders of the message template of a failed rule, field is the name of the field.
+// It is used by the Validate methods written by validatorgen
+func FormatMessage(template, field string, fe *FieldError) string {
+	return formatMessage(template, field, fe, nil)
+}
+
+// formatMessage replaces {field}, {path}, {rule}, {param}, {value} and the placeholder of the rule param,
+// e.g. {min} or {len}, in a single pass: placeholders written by a value are never replaced.
+// Unknown placeholders are kept as they are, the replacements are escaped by escapeMessage
+func formatMessage(template, field string, fe *FieldError, extra map[string]string) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+	var sb strings.Builder
+	for {
+		open := strings.IndexByte(template, '{')
+		if open == -1 {
+			break
+		}
+		size := strings.IndexByte(template[open:], '}')
+		if size == -1 {
+			break
+		}
+		name := template[open+1 : open+size]
+		text, ok := extra[name]
+		if !ok {
+			text, ok = messageParam(name, field, fe)
+		}
+		if !ok {
+			// keep the brace and look for a placeholder right after it, e.g. in {{value}
+			sb.WriteString(template[:open+1])
+			template = template[open+1:]
+			continue
+		}
+		sb.WriteString(template[:open])
+		sb.WriteString(escapeMessage(text))
+		template = template[open+size+1:]
+	}
+	sb.WriteString(template)
+	return sb.String()
+}
+
+// messageParam return the text of the placeholder name
+func messageParam(name, field string, fe *FieldError) (string, bool) {
+	switch name {
+	case "field":
+		return field, true
+	case "path":
+		return fe.Path, true
+	case "rule":
+		return fe.Rule, true
+	case "param":
+		return fe.Param, true
+	case "value":
+		return messageValue(fe.Value), true
+	}
+	if ruleParams[fe.Rule] == name {
+		return fe.Param, true
+	}
+	return "", false
+}
+
+// messageValue formats the value of a field for a message, nil values and pointers are empty
+func messageValue(value interface{}) string {
+	rv := derefValue(reflect.ValueOf(value))
+	if !rv.IsValid() {
+		return ""
+	}
+	if rv.CanInterface() {
+		value = rv.Interface()
+	}
+	return fmt.Sprint(value)
+}
+
+// escapeMessage quotes the non-printable characters of a placeholder replacement,
+// and escapes the | separating the languages of a message, e.g. in the value of enum(a|b)
+func escapeMessage(text string) string {
+	if !strings.Contains(text, "|") && strings.IndexFunc(text, func(r rune) bool { return !unicode.IsPrint(r) }) == -1 {
+		return text
+	}
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '|':
+			sb.WriteString(`\|`)
+		case !unicode.IsPrint(r):
+			quoted := strconv.QuoteRune(r)
+			sb.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// splitLangs splits a message into its languages at every | not escaped by \, and unescapes \|
+func splitLangs(msg string) []string {
+	if !strings.Contains(msg, `\|`) {
+		return strings.Split(msg, "|")
+	}
+	var langs []string
+	var sb strings.Builder
+	for i := 0; i < len(msg); i++ {
+		switch {
+		case msg[i] == '\\' && i+1 < len(msg) && msg[i+1] == '|':
+			sb.WriteByte('|')
+			i++
+		case msg[i] == '|':
+			langs = append(langs, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(msg[i])
+		}
+	}
+	return append(langs, sb.String())
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"testing"
+)
+
+func TestFormatMessage(t *testing.T) {
+	fe := &FieldError{"Items[1].Sku", "length", "3", "ab", ""}
+	for _, tc := range []struct {
+		name, template, expect string
+	}{
+		{"Plain", "fail", "fail"},
+		{"Field", "{field} is bad", "Sku is bad"},
+		{"Path", "{path}: {rule}({param})", "Items[1].Sku: length(3)"},
+		{"Value", "got {value}, want {len}", "got ab, want 3"},
+		{"OtherRuleParam", "want {min}", "want {min}"},
+		{"Unknown", "use {a-z} {x", "use {a-z} {x"},
+		{"Nested", "{{value}}", "{ab}"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if msg := FormatMessage(tc.template, "Sku", fe); msg != tc.expect {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.expect, msg)
+			}
+		})
+	}
+}
+
+func TestFormatMessage_Escape(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		value  interface{}
+		expect string
+	}{
+		{"Placeholder", "{field}", "{field}"},
+		{"Separator", "a|b", `a\|b`},
+		{"NonPrintable", "a\nb\x00", `a\nb\x00`},
+		{"Ptr", stringPtr("a"), "a"},
+		{"NilPtr", (*string)(nil), ""},
+		{"Nil", nil, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if msg := FormatMessage("{value}", "Value", &FieldError{Value: tc.value}); msg != tc.expect {
+				t.Fatalf("%s failed: expect [%s], but got [%s]\n", t.Name(), tc.expect, msg)
+			}
+		})
+	}
+}
+
+func TestMessageTemplates(t *testing.T) {
+	type testTemplate struct {
+		Name    string `validate:"minlength(3,{field} needs {min} characters, got {value}|{field} 至少 {min} 个字符)"`
+		Age     int    `validate:"min(18) max(60) msg({field} must be between 18 and 60|{field} 应在 18 到 60 之间)"`
+		Role    string `validate:"enum(admin|user,want {enum}|应为 {enum})"`
+		Confirm string `validate:"eqfield(Name)"`
+		Email   string `validate:"required_with(Name)"`
+		Phone   string `validate:"required_with(Name,{field} is required|{field} 必填)"`
+	}
+	result := New(&testTemplate{Name: "go", Age: 70, Role: "root", Confirm: "go", Email: "go@golang.org"}).Lang("en", "zh").Validate()
+	for _, tc := range []struct {
+		lang, expect string
+	}{
+		{"en", "Name needs 3 characters, got go,Age must be between 18 and 60,want admin|user,Phone is required"},
+		{"zh", "Name 至少 3 个字符,Age 应在 18 到 60 之间,应为 admin|user,Phone 必填"},
+	} {
+		if msg := result.Messages(tc.lang); msg != tc.expect {
+			t.Fatalf("test failed: expect [%s], but got [%s]\n", tc.expect, msg)
+		}
+	}
+	if msg := result.Items[2].Message; msg != `want admin\|user|应为 admin\|user` {
+		t.Fatalf("test failed: expect escaped enum, but got [%s]\n", msg)
+	}
+	result = New(&testTemplate{Name: "gopher", Age: 18, Role: "user", Confirm: "go", Phone: "1"}).Validate()
+	if msg := result.Messages(); msg != "Confirm must be equal to Name,Email is required when Name is present" {
+		t.Fatalf("test failed: expect default messages, but got [%s]\n", msg)
+	}
+}
+
+func TestMessageTemplates_Var(t *testing.T) {
+	if msg := Var("{path}", "length(3,{value} is not {len} long)").Messages(); msg != "{path} is not 3 long" {
+		t.Fatalf("test failed: expect [{path} is not 3 long], but got [%s]\n", msg)
+	}
+}
@@ -13,18 +13,10 @@ package validator
 
 import (
 	"errors"
+	"reflect"
 	"strconv"
-	"strings"
 )
 
-func findSpIdx(str string) int {
-	idx := strings.Index(str, "[,]")
-	if idx == -1 {
-		idx = strings.Index(str, ",")
-	}
-	return idx
-}
-
 func bytePtr(i byte) *byte                    { return &i }
 func runePtr(i rune) *rune                    { return &i }
 func int8Ptr(i int8) *int8                    { return &i }
@@ -40,3 +32,17 @@ func arrayPtr(i []interface{}) *[]interface{} { return &i }
 func parseNumError(fN, v string) error {
 	return &strconv.NumError{Func: fN, Num: v, Err: errors.New("invalid syntax")}
 }
+
+// errFunc fails every value with the error of a rule that cannot be parsed
+type errFunc struct{ err error }
+
+// Valid method
+func (f *errFunc) Valid(reflect.Value) (bool, string) { return false, f.err.Error() }
+
+// vFuncOf returns vf, or an errFunc failing every value when err is not nil
+func vFuncOf(vf VFunc, err error) VFunc {
+	if err != nil {
+		return &errFunc{err}
+	}
+	return vf
+}
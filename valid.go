@@ -24,20 +24,21 @@ type validFunc struct {
 }
 
 // ValidFunc method
-func ValidFunc(str string) VFunc {
+func ValidFunc(str string) VFunc { return vFuncOf(parseValidFunc(str)) }
+
+func parseValidFunc(str string) (VFunc, error) {
 	if str == "" {
-		return nil
+		return nil, nil
 	}
-	vStr, msg := str, ""
-	if spIdx := findSpIdx(str); spIdx != -1 {
-		vStr = str[:spIdx]
-		msg = str[spIdx+1:]
+	vStr, msg, err := splitMsg(str)
+	if err != nil {
+		return nil, err
 	}
-	if v, err := strconv.ParseBool(vStr); err != nil {
-		panic(err)
-	} else {
-		return &validFunc{v, msg}
+	v, err := strconv.ParseBool(vStr)
+	if err != nil {
+		return nil, err
 	}
+	return &validFunc{v, msg}, nil
 }
 
 // Valid method
@@ -48,12 +49,19 @@ func (f *validFunc) Valid(value reflect.Value) (bool, string) {
 
 	if reflectx.IsArray(value.Type()) || reflectx.IsSlice(value.Type()) {
 		for i := 0; i < value.Len(); i++ {
-			return f.Valid(value.Index(i))
+			if passed, msg := f.Valid(value.Index(i)); !passed {
+				return passed, msg
+			}
 		}
 	}
 
-	if reflectx.IsPtr(value.Type()) && value.IsNil() {
-		return false, f.msg
+	if reflectx.IsPtr(value.Type()) {
+		if value.IsNil() {
+			return false, f.msg
+		}
+		if reflectx.IsPtr(value.Type().Elem()) {
+			return f.Valid(value.Elem())
+		}
 	}
 
 	return true, ""
@@ -22,7 +22,7 @@ func TestNewValidFunc(t *testing.T) {
 	}
 }
 
-func TestValidPanic(t *testing.T) {
+func TestValidErr(t *testing.T) {
 	for _, tc := range []struct {
 		name  string
 		str   string
@@ -32,17 +32,10 @@ func TestValidPanic(t *testing.T) {
 		{"ParseBool", "A", reflect.ValueOf(true), parseNumError("ParseBool", "A")},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			defer func() {
-				re := recover()
-				if re == nil {
-					t.Fatalf("%s failed: error expect not nil", t.Name())
-				}
-				if re == nil || !reflect.DeepEqual(tc.err, re) {
-					t.Fatalf("%s failed: error expect [%v], but got [%v]\n", t.Name(), tc.err, re)
-				}
-			}()
-			vF := ValidFunc(tc.str)
-			_, _ = vF.Valid(tc.value)
+			passed, msg := ValidFunc(tc.str).Valid(tc.value)
+			if passed || msg != tc.err.Error() {
+				t.Fatalf("%s failed: expect [false %v], but got [%v %v]\n", t.Name(), tc.err, passed, msg)
+			}
 		})
 	}
 }
@@ -56,6 +49,11 @@ func TestValid(t *testing.T) {
 
 		{"StructPtrArr", ValidFunc("T"), reflect.ValueOf([2]*struct{}{}), false, ""},
 		{"StructPtrArr", ValidFunc("T,fail"), reflect.ValueOf([2]*struct{}{}), false, "fail"},
+		{"StructPtrArrLastNil", ValidFunc("T,fail"), reflect.ValueOf([2]*struct{}{{}, nil}), false, "fail"},
+		{"StructPtrSlice", ValidFunc("T"), reflect.ValueOf([]*struct{}{{}, {}}), true, ""},
+
+		{"NilPtrPtr", ValidFunc("T"), reflect.ValueOf(func() **struct{} { var p *struct{}; return &p }()), false, ""},
+		{"PtrPtr", ValidFunc("T"), reflect.ValueOf(func() **struct{} { p := &struct{}{}; return &p }()), true, ""},
 	} {
 		t.Run(s.name, s.test)
 	}
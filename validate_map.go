@@ -0,0 +1,87 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidateMap validates dynamic data, e.g. decoded JSON, rules map a key to a validate tag.
+// Keys are dotted paths through nested maps, slices and arrays on the way are walked element by element,
+// e.g. "items.sku" validates the sku of every item. Missing keys, or keys under missing keys or scalars, only fail
+// presence rules like required.
+//
+// Cross-field rules resolve other keys from the map declaring the key first, then from data.
+// Malformed rules never panic: their keys are reported as un-passed and Result.Err returns them as TagErrors.
+func ValidateMap(data map[string]interface{}, rules map[string]string) *Result {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	root := reflect.ValueOf(data)
+	w := &walker{ctx: context.Background(), root: root, nameFunc: GoName, registry: DefaultRegistry, catalog: DefaultCatalog}
+	var errs TagErrors
+	for _, key := range keys {
+		es, tagErrs := loadTag(nil, rules[key])
+		for _, err := range tagErrs {
+			err := *err
+			err.Field = key
+			errs = append(errs, &err)
+		}
+		w.validateKey(es, root, root, strings.Split(key, "."), "")
+	}
+	passed := len(errs) == 0
+	for _, item := range w.items {
+		if !item.Passed {
+			passed = false
+			break
+		}
+	}
+	result := newResult(data, w.items, passed, nil)
+	if len(errs) > 0 {
+		result.err = errs
+	}
+	return result
+}
+
+// validateKey validates the value reached by the names from value, parent is the map declaring value
+func (w *walker) validateKey(es *elemSchema, parent, value reflect.Value, names []string, path string) {
+	if len(names) == 0 {
+		field := &reflect.StructField{Name: path[strings.LastIndexAny(path, ".]")+1:]}
+		w.validateElem(field, es, parent, value, path)
+		return
+	}
+	switch value = derefValue(value); value.Kind() {
+	case reflect.Map:
+		keyType := value.Type().Key()
+		if keyType.Kind() != reflect.String {
+			return
+		}
+		w.validateKey(es, value, value.MapIndex(reflect.ValueOf(names[0]).Convert(keyType)), names[1:], joinPath(path, names[0], ""))
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			w.validateKey(es, parent, value.Index(i), names, indexPath(path, i))
+		}
+	case reflect.Struct:
+		// fields of structs are not reached by keys
+	default:
+		// the key is missing with its intermediate keys, like the keys under a scalar
+		for _, name := range names {
+			path = joinPath(path, name, "")
+		}
+		w.validateKey(es, parent, reflect.Value{}, nil, path)
+	}
+}
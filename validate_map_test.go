@@ -0,0 +1,121 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateMap(t *testing.T) {
+	rules := map[string]string{
+		"name":            "required(T) minlength(2) maxlength(10)",
+		"age":             "min(18) max(130)",
+		"password":        "required(T)",
+		"confirm":         "eqfield(password,passwords differ)",
+		"tags":            "maxsize(2) dive(minlength(1))",
+		"address.city":    "required(T)",
+		"items.sku":       "regex(^[A-Z]+$)",
+		"items.qty":       "min(1)",
+		"labels":          "keys(regex(^[a-z]+$)) values(min(0))",
+		"nickname":        "omitempty(T) minlength(3)",
+		"optional.nested": "minlength(2)",
+	}
+	for _, tc := range []struct {
+		name   string
+		data   string
+		errors map[string][]string
+	}{
+		{"Valid", `{"name":"john","age":20,"password":"a","confirm":"a","tags":["a"],"address":{"city":"x"},
+			"items":[{"sku":"A","qty":1}],"labels":{"a":1}}`, map[string][]string{}},
+		{"Missing", `{}`, map[string][]string{"name": {"required"}, "password": {"required"}, "address.city": {"required"}}},
+		{"Null", `{"name":null,"password":"a","confirm":"a","nickname":null,"address":null}`,
+			map[string][]string{"name": {"required"}, "address.city": {"required"}}},
+		{"Scalar", `{"name":"john","password":"a","confirm":"a","address":"Paris","items":[1,"A"]}`,
+			map[string][]string{"address.city": {"required"}}},
+		{"Invalid", `{"name":"j","age":12,"password":"a","confirm":"b","tags":["a",""],"address":{},
+			"items":[{"sku":"A","qty":0},{"sku":"b","qty":2}],"labels":{"B":-1},"nickname":"ab"}`, map[string][]string{
+			"name": {"minlength"}, "age": {"min"}, "confirm": {"eqfield"}, "tags[1]": {"minlength"}, "address.city": {"required"},
+			"items[0].qty": {"min"}, "items[1].sku": {"regex"}, `labels["B"]`: {"regex", "min"}, "nickname": {"minlength"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.data), &data); err != nil {
+				t.Fatal(err)
+			}
+			r := ValidateMap(data, rules)
+			errs := make(map[string][]string)
+			for _, item := range r.Items {
+				for _, fe := range item.Errors {
+					errs[fe.Path] = append(errs[fe.Path], fe.Rule)
+				}
+			}
+			if !reflect.DeepEqual(errs, tc.errors) {
+				t.Fatalf("%s failed: expect %v, but got %v\n", t.Name(), tc.errors, errs)
+			}
+			if r.Passed != (len(errs) == 0) {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), len(errs) == 0, r.Passed)
+			}
+			if err := r.Err(); (err == nil) != (len(errs) == 0) || (err != nil && !errors.Is(err, ErrRequired) && !errors.Is(err, ErrMin)) {
+				t.Fatalf("%s failed: unexpected err [%v]\n", t.Name(), err)
+			}
+		})
+	}
+	r := ValidateMap(map[string]interface{}{"name": "john", "confirm": "b", "password": "a", "address": map[string]interface{}{"city": "x"}}, rules)
+	if msg := r.Messages(); msg != "passwords differ" {
+		t.Fatalf("test failed: msg expect [passwords differ], but got [%v]\n", msg)
+	}
+}
+
+func TestValidateMapErr(t *testing.T) {
+	r := ValidateMap(map[string]interface{}{"a": 1, "b": 2}, map[string]string{"a": "min(A)", "b": "dive(min(1)", "c": "min(1)"})
+	if r.Passed {
+		t.Fatal("test failed: expect un-passed")
+	}
+	errs, ok := r.Err().(TagErrors)
+	if !ok || len(errs) != 2 || errs[0].Field != "a" || errs[1].Field != "b" || errs[1].Rule != "dive" {
+		t.Fatalf("test failed: expect TagErrors of a and b, but got [%v]\n", r.Err())
+	}
+	for _, item := range r.Items[:2] {
+		if item.Passed || item.Errors[0].Rule != "tag" {
+			t.Fatalf("test failed: expect %s un-passed by its tag\n", item.Path)
+		}
+	}
+}
+
+func TestValidateMapMissingParent(t *testing.T) {
+	r := ValidateMap(map[string]interface{}{}, map[string]string{"address.city": "required(true)", "address.zip": "length(5)"})
+	if r.Passed || len(r.Items) != 2 || r.Items[0].Path != "address.city" || r.Items[0].Errors[0].Rule != "required" {
+		t.Fatalf("test failed: expect address.city un-passed by required, but got [%v]\n", r.Items)
+	}
+	if !r.Items[1].Passed {
+		t.Fatalf("test failed: expect address.zip passed, but got [%v]\n", r.Items[1])
+	}
+}
+
+func TestValidateMapEnumKind(t *testing.T) {
+	r := ValidateMap(map[string]interface{}{"k": 5}, map[string]string{"k": "enum(a|b)"})
+	if r.Passed || r.Items[0].Errors[0].Rule != "enum" {
+		t.Fatalf("test failed: expect k un-passed by enum, but got [%v]\n", r.Items)
+	}
+}
+
+func BenchmarkValidateMap(b *testing.B) {
+	data := map[string]interface{}{"name": "john", "items": []interface{}{map[string]interface{}{"sku": "A"}}}
+	rules := map[string]string{"name": "required(T) minlength(2)", "items.sku": "regex(^[A-Z]+$)"}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		ValidateMap(data, rules)
+	}
+}
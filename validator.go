@@ -12,46 +12,266 @@
 package validator
 
 import (
-	"github.com/billcoding/reflectx"
+	"context"
+	"fmt"
 	"reflect"
+	"sort"
 )
 
 // Validator defines validator struct
 type Validator struct {
 	structPtr interface{}
-	fields    []*reflect.StructField
-	values    []*reflect.Value
-	items     []interface{}
+	schema    *structSchema
+	err       error
 	lang      []string
+	nameFunc  NameFunc
+	path      string
+	firstRule bool
+	failFast  bool
+	registry  *Registry
+	catalog   *Catalog
 }
 
 // New return new *Validator
+//
+// Nested structs, embedded structs, struct pointers and slices, arrays or maps of structs
+// are validated recursively, use nested(false) to skip a field or "-" to ignore it entirely.
+//
+// Tags are parsed once per struct type, the compiled rules are cached and shared by every New.
+//
+// Malformed tags never panic: they are collected and returned by Err,
+// and the fields that declare them are reported as un-passed by Validate.
 func New(structPtr interface{}) *Validator {
-	fields, values, tags := reflectx.ParseTag(structPtr, new(Item), "alias", "validate", true)
-	return &Validator{structPtr, fields, values, tags, nil}
+	schema, err := loadSchema(reflect.TypeOf(structPtr).Elem())
+	return &Validator{structPtr: structPtr, schema: schema, err: err}
 }
 
-// Lang set supported lang
-func (v *Validator) Lang(lang ...string) *Validator { v.lang = lang; return v }
+// Err return the malformed tag errors as TagErrors, or nil
+func (v *Validator) Err() error { return v.err }
+
+// NameFunc set the func naming fields in ResultItem.Path, GoName by default
+func (v *Validator) NameFunc(nameFunc NameFunc) *Validator { v.nameFunc = nameFunc; return v }
+
+// Path set the path of the validated struct, prefixing the paths of its fields, e.g. Address.City for Path("Address").
+// It is used by the Validate methods written by validatorgen for nested structs
+func (v *Validator) Path(path string) *Validator { v.path = path; return v }
+
+// StopOnFirstRule set whether to stop validating a field at its first failed rule,
+// by default every rule is run and reported in ResultItem.Errors
+func (v *Validator) StopOnFirstRule(firstRule bool) *Validator { v.firstRule = firstRule; return v }
+
+// FailFast set whether to stop validating at the first un-passed field,
+// Result.Items then ends with that field
+func (v *Validator) FailFast(failFast bool) *Validator { v.failFast = failFast; return v }
+
+// Registry set the registry of custom validators, DefaultRegistry by default
+func (v *Validator) Registry(registry *Registry) *Validator { v.registry = registry; return v }
+
+// Catalog set the catalog of default messages, DefaultCatalog by default
+func (v *Validator) Catalog(catalog *Catalog) *Validator { v.catalog = catalog; return v }
+
+// Lang set the ordered supported lang of the server as BCP 47 tags, e.g. Lang("zh-CN", "en").
+// Messages of tags hold one translation per lang separated by |, default messages are given in every lang, DefaultLang by default.
+// Accept-Language headers are matched against them by Result.Messages, Result.MatchLang and Result.Render
+func (v *Validator) Lang(lang ...string) *Validator {
+	v.lang = make([]string, len(lang))
+	for i, l := range lang {
+		v.lang[i] = canonicalLang(l)
+	}
+	return v
+}
 
 // Validate return validation result
-func (v *Validator) Validate() *Result {
-	resultItems := make([]*ResultItem, len(v.fields))
-	passedCount := 0
-	for pos := range v.fields {
-		field := v.fields[pos]
-		value := v.values[pos]
-		item := v.items[pos].(*Item)
-		resultItem := validate(item, field, value)
-		resultItems[pos] = resultItem
-		if resultItem.Passed {
-			passedCount++
+func (v *Validator) Validate() *Result { return v.ValidateContext(context.Background()) }
+
+// ValidateContext return validation result, ctx is passed to context-aware custom validators.
+// Validation stops when ctx is done, the result is then un-passed and Result.Err returns ctx.Err()
+func (v *Validator) ValidateContext(ctx context.Context) *Result {
+	value := reflect.ValueOf(v.structPtr)
+	if value.IsNil() {
+		value = reflect.New(value.Type().Elem())
+	}
+	nameFunc := v.nameFunc
+	if nameFunc == nil {
+		nameFunc = GoName
+	}
+	registry := v.registry
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	catalog := v.catalog
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+	w := &walker{ctx: ctx, root: value.Elem(), nameFunc: nameFunc, registry: registry, catalog: catalog, lang: v.lang,
+		firstRule: v.firstRule, failFast: v.failFast, visited: make(map[visit]struct{})}
+	w.validateNested(v.schema, value, v.path)
+	passed := w.err == nil
+	for _, item := range w.items {
+		if !item.Passed {
+			passed = false
+			break
+		}
+	}
+	result := newResult(v.structPtr, w.items, passed, v.lang)
+	result.err = w.err
+	return result
+}
+
+// visit identifies a struct pointer being walked, pointers are only skipped inside their own walk to break cycles
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// walker walks a struct value along its schema
+type walker struct {
+	ctx       context.Context
+	root      reflect.Value
+	err       error
+	items     []*ResultItem
+	nameFunc  NameFunc
+	registry  *Registry
+	catalog   *Catalog
+	lang      []string
+	firstRule bool
+	failFast  bool
+	stopped   bool
+	visited   map[visit]struct{}
+}
+
+func (w *walker) validateStruct(s *structSchema, value reflect.Value, path string) {
+	for _, fs := range s.fields {
+		if w.stopped {
+			return
+		}
+		if err := w.ctx.Err(); err != nil {
+			w.err, w.stopped = err, true
+			return
+		}
+		fieldValue := value.Field(fs.field.Index[0])
+		name := w.nameFunc(fs.field)
+		fieldPath := joinPath(path, name, fs.field.Name)
+		if fs.item != nil {
+			resultItem := w.validate(&fs.field, fs.item, fs.rules, value, fieldValue, fieldPath)
+			if resultItem == nil {
+				// conditions not met, the field is skipped entirely
+				continue
+			}
+			if w.add(resultItem); w.stopped {
+				return
+			}
+			if w.validateElems(&fs.field, &fs.elemSchema, value, fieldValue, fieldPath); w.stopped {
+				return
+			}
+		}
+		if fs.nested != nil {
+			if fs.field.Anonymous && name == "" {
+				// promoted fields of an embedded struct
+				fieldPath = path
+			}
+			w.validateNested(fs.nested, fieldValue, fieldPath)
+		}
+	}
+}
+
+func (w *walker) validateNested(s *structSchema, value reflect.Value, path string) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+		key := visit{value.Pointer(), value.Type()}
+		if _, ok := w.visited[key]; ok {
+			return
+		}
+		w.visited[key] = struct{}{}
+		w.validateNested(s, value.Elem(), path)
+		delete(w.visited, key)
+	case reflect.Struct:
+		w.validateStruct(s, value, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len() && !w.stopped; i++ {
+			w.validateNested(s, value.Index(i), indexPath(path, i))
+		}
+	case reflect.Map:
+		keys := sortedKeys(value)
+		for i := 0; i < len(keys) && !w.stopped; i++ {
+			w.validateNested(s, value.MapIndex(keys[i]), keyPath(path, keys[i]))
+		}
+	}
+}
+
+// validateElems validates every map key and value and every slice or array element having rules,
+// their results are named by their key or index
+func (w *walker) validateElems(field *reflect.StructField, es *elemSchema, parent, value reflect.Value, path string) {
+	if es.keys == nil && es.values == nil && es.dive == nil {
+		return
+	}
+	if value = derefValue(value); !value.IsValid() {
+		return
+	}
+	switch value.Kind() {
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			entryPath := keyPath(path, key)
+			w.validateElem(field, es.keys, parent, key, entryPath)
+			w.validateElem(field, es.values, parent, value.MapIndex(key), entryPath)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			w.validateElem(field, es.dive, parent, value.Index(i), indexPath(path, i))
 		}
 	}
-	return newResult(v.structPtr, resultItems, len(v.items) == passedCount, v.lang)
 }
 
-func validate(item *Item, field *reflect.StructField, value *reflect.Value) *ResultItem {
-	passed, msg := item.Validate(*field, *value)
-	return &ResultItem{Field: field, Passed: passed, Message: msg}
+// validateElem validates an element and its own elements
+func (w *walker) validateElem(field *reflect.StructField, es *elemSchema, parent, value reflect.Value, path string) {
+	if es == nil || w.stopped {
+		return
+	}
+	if resultItem := w.validate(field, es.item, es.rules, parent, value, path); resultItem != nil {
+		if w.add(resultItem); !w.stopped {
+			w.validateElems(field, es, parent, value, path)
+		}
+	}
+}
+
+// add adds the result of a field, stopping at an un-passed field when failFast
+func (w *walker) add(resultItem *ResultItem) {
+	w.items = append(w.items, resultItem)
+	if !resultItem.Passed && w.failFast {
+		w.stopped = true
+	}
+}
+
+// validate return the result of the field, or nil when it is skipped by its conditional rules
+func (w *walker) validate(field *reflect.StructField, item *Item, rules []*rule, parent, value reflect.Value, path string) *ResultItem {
+	resultItem := &ResultItem{Field: field, Path: path}
+	if rules == nil {
+		fe := &FieldError{path, "tag", "", valueInterface(value), item.Msg}
+		if _, keyed := messageKey(fe.Message); fe.Message == "" || keyed {
+			fe.Message = w.catalog.message(w.lang, field.Name, fe, nil)
+		}
+		resultItem.Message, resultItem.Errors = fe.Message, []*FieldError{fe}
+		return resultItem
+	}
+	for value.Kind() == reflect.Interface {
+		// dynamic values like decoded JSON are validated by their concrete value, nil ones are missing
+		value = value.Elem()
+	}
+	info := &FieldInfo{w.root, parent, *field, value, path}
+	if !conditionMet(rules, info) {
+		return nil
+	}
+	resultItem.Message, resultItem.Errors = item.validate(w.ctx, rules, w.registry.lookup(item.Custom), info, w.firstRule, w.catalog, w.lang)
+	resultItem.Passed = len(resultItem.Errors) == 0
+	return resultItem
+}
+
+// sortedKeys return the keys of a map in a stable order
+func sortedKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+	return keys
 }
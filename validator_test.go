@@ -13,6 +13,7 @@ package validator
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -49,7 +50,7 @@ func TestNew(t *testing.T) {
 
 		{"IntUnPass", &struct {
 			int `validate:"min(1)"`
-		}{}, 1, false, ""},
+		}{}, 1, false, "int must be at least 1"},
 		{"IntUnPassAndMsg", &struct {
 			int `validate:"min(1,fail)"`
 		}{}, 1, false, "fail"},
@@ -62,7 +63,7 @@ func TestNew(t *testing.T) {
 
 		{"StringUnPass", &struct {
 			string `validate:"minlength(1)"`
-		}{}, 1, false, ""},
+		}{}, 1, false, "string must have a length of at least 1"},
 		{"StringUnPassAndMsg", &struct {
 			string `validate:"minlength(1,fail)"`
 		}{}, 1, false, "fail"},
@@ -75,7 +76,7 @@ func TestNew(t *testing.T) {
 
 		{"FloatUnPass", &struct {
 			float64 `validate:"min(1)"`
-		}{}, 1, false, ""},
+		}{}, 1, false, "float64 must be at least 1"},
 		{"FloatUnPassAndMsg", &struct {
 			float64 `validate:"min(1,fail)"`
 		}{}, 1, false, "fail"},
@@ -91,8 +92,8 @@ func TestNew(t *testing.T) {
 			if v == nil {
 				t.Fatalf("%s test failed: validator is nil\n", tc.name)
 			}
-			if tc.expectLen != len(v.items) {
-				t.Fatalf("%s test failed: expect len [%d], but got [%d]\n", tc.name, tc.expectLen, len(v.items))
+			if tc.expectLen != len(v.schema.fields) {
+				t.Fatalf("%s test failed: expect len [%d], but got [%d]\n", tc.name, tc.expectLen, len(v.schema.fields))
 			}
 			r := v.Validate()
 			if tc.passed != r.Passed {
@@ -111,4 +112,548 @@ func TestValidator_Lang(t *testing.T) {
 	if !reflect.DeepEqual(v.lang, langS) {
 		t.Fatalf("test failed: expect [%s], but got [%s]\n", langS, v.lang)
 	}
+	for _, tc := range []struct {
+		lang   []string
+		expect []string
+	}{
+		{[]string{"zh_cn", "EN"}, []string{"zh-CN", "en"}},
+		{[]string{"zh-TW,zh;q=0.9,en;q=0.8"}, []string{"zh-TW,zh;q=0.9,en;q=0.8"}},
+		{[]string{"chinese", ""}, []string{"chinese", ""}},
+	} {
+		if v.Lang(tc.lang...); !reflect.DeepEqual(v.lang, tc.expect) {
+			t.Fatalf("test failed: %v expect %v, but got %v\n", tc.lang, tc.expect, v.lang)
+		}
+	}
+	msg := New(&struct {
+		Age int `validate:"min(18)"`
+	}{}).Lang("en", "zh-TW").Validate().Messages("fr-CA;q=0.5,zh-Hant")
+	if msg != "Age不能小于18" {
+		t.Fatalf("test failed: expect [Age不能小于18], but got [%s]\n", msg)
+	}
+	r := New(&struct {
+		Age int `validate:"min(18,too young|太小)"`
+	}{}).Lang("en", "zh-CN").Validate()
+	if msg := r.Messages("zh-CN,zh;q=0.9,en;q=0.8"); msg != "太小" {
+		t.Fatalf("test failed: expect [太小], but got [%s]\n", msg)
+	}
+	if msg := r.Messages("fr"); msg != "too young" {
+		t.Fatalf("test failed: expect [too young], but got [%s]\n", msg)
+	}
+}
+
+func TestValidator_Err(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+		rules     []string
+	}{
+		{"NoErr", &struct {
+			int `validate:"min(1) max(10)"`
+		}{}, nil},
+		{"Min", &struct {
+			int `validate:"min(A)"`
+		}{}, []string{"min"}},
+		{"MinAndMax", &struct {
+			int `validate:"min(A) max(B)"`
+		}{}, []string{"min", "max"}},
+		{"Fields", &struct {
+			int    `validate:"length(A)"`
+			string `validate:"arr_length(B) regex([)"`
+		}{}, []string{"length", "size", "regex"}},
+		{"Valid", &struct {
+			*int `validate:"valid(A)"`
+		}{}, []string{"valid"}},
+		{"EnumInt", &struct {
+			int `validate:"enum(1|A)"`
+		}{}, []string{"enum"}},
+		{"EnumUintSlice", &struct {
+			U []*uint `validate:"enum(1|A)"`
+		}{}, []string{"enum"}},
+		{"EnumString", &struct {
+			string `validate:"enum(1|A)"`
+		}{}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New(tc.structPtr)
+			err := v.Err()
+			if len(tc.rules) == 0 {
+				if err != nil {
+					t.Fatalf("%s test failed: expect nil err, but got [%v]\n", tc.name, err)
+				}
+				return
+			}
+			errs, ok := err.(TagErrors)
+			if !ok {
+				t.Fatalf("%s test failed: expect TagErrors, but got [%T]\n", tc.name, err)
+			}
+			if len(errs) != len(tc.rules) {
+				t.Fatalf("%s test failed: expect len [%d], but got [%d]\n", tc.name, len(tc.rules), len(errs))
+			}
+			for i, e := range errs {
+				if e.Rule != tc.rules[i] {
+					t.Fatalf("%s test failed: expect rule [%s], but got [%s]\n", tc.name, tc.rules[i], e.Rule)
+				}
+				if e.Struct != reflect.TypeOf(tc.structPtr).Elem() {
+					t.Fatalf("%s test failed: expect struct [%v], but got [%v]\n", tc.name, reflect.TypeOf(tc.structPtr).Elem(), e.Struct)
+				}
+			}
+			if r := v.Validate(); r.Passed {
+				t.Fatalf("%s test failed: expect passed [false], but got [%v]\n", tc.name, r.Passed)
+			}
+		})
+	}
+}
+
+func TestValidator_EnumInterface(t *testing.T) {
+	r := New(&struct {
+		V interface{} `validate:"enum(a|b)"`
+	}{V: 5}).Validate()
+	if r.Passed || r.Items[0].Errors[0].Rule != "enum" {
+		t.Fatalf("test failed: expect V un-passed by enum, but got [%v]\n", r.Items)
+	}
+}
+
+type testNestedAddress struct {
+	City string `validate:"minlength(1,city)"`
+}
+
+type testNestedItem struct {
+	Sku string `validate:"minlength(1,sku)"`
+}
+
+type testNestedNode struct {
+	Name string `validate:"minlength(1,name)"`
+	Next *testNestedNode
+}
+
+type testNestedEmbed struct {
+	ID int `validate:"min(1,id)"`
+}
+
+func TestValidator_Nested(t *testing.T) {
+	cycle := &testNestedNode{Name: "a"}
+	cycle.Next = &testNestedNode{Next: cycle}
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+		passed    bool
+		msg       string
+	}{
+		{"Struct", &struct {
+			Address testNestedAddress
+		}{}, false, "city"},
+		{"StructPass", &struct {
+			Address testNestedAddress
+		}{testNestedAddress{"a"}}, true, ""},
+		{"Embedded", &struct {
+			testNestedEmbed
+		}{}, false, "id"},
+		{"Ptr", &struct {
+			Address *testNestedAddress
+		}{&testNestedAddress{}}, false, "city"},
+		{"NilPtr", &struct {
+			Address *testNestedAddress
+		}{}, true, ""},
+		{"NilPtrValid", &struct {
+			Address *testNestedAddress `validate:"valid(T,address)"`
+		}{}, false, "address"},
+		{"Slice", &struct {
+			Items []testNestedItem
+		}{[]testNestedItem{{"a"}, {}, {"b"}, {}}}, false, "sku,sku"},
+		{"PtrSlice", &struct {
+			Items []*testNestedItem
+		}{[]*testNestedItem{{"a"}, nil, {}}}, false, "sku"},
+		{"Array", &struct {
+			Items [2]testNestedItem
+		}{}, false, "sku,sku"},
+		{"Map", &struct {
+			Items map[string]testNestedItem
+		}{map[string]testNestedItem{"a": {}, "b": {"b"}}}, false, "sku"},
+		{"NestedSlice", &struct {
+			Items [][]*testNestedItem
+		}{[][]*testNestedItem{{{}}, {{"a"}, {}}}}, false, "sku,sku"},
+		{"TaggedAndNested", &struct {
+			Items []testNestedItem `validate:"arr_minlength(3,items)"`
+		}{[]testNestedItem{{}}}, false, "items,sku"},
+		{"NestedFalse", &struct {
+			Items []testNestedItem `validate:"nested(false)"`
+		}{[]testNestedItem{{}}}, true, ""},
+		{"Ignored", &struct {
+			Address testNestedAddress `validate:"-"`
+		}{}, true, ""},
+		{"Cycle", &struct {
+			Node *testNestedNode
+		}{cycle}, false, "name"},
+		{"Deep", &struct {
+			Order struct {
+				Items []struct {
+					Address *testNestedAddress
+				}
+			}
+		}{}, true, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New(tc.structPtr)
+			if err := v.Err(); err != nil {
+				t.Fatalf("%s test failed: expect nil err, but got [%v]\n", tc.name, err)
+			}
+			r := v.Validate()
+			if tc.passed != r.Passed {
+				t.Fatalf("%s test failed: expect passed [%v], but got [%v]\n", tc.name, tc.passed, r.Passed)
+			}
+			if tc.msg != r.Messages() {
+				t.Fatalf("%s test failed: expect msg [%s], but got [%s]\n", tc.name, tc.msg, r.Messages())
+			}
+		})
+	}
+}
+
+func TestValidator_SharedPtr(t *testing.T) {
+	a := &testNestedAddress{}
+	r := New(&struct {
+		Billing  *testNestedAddress
+		Shipping *testNestedAddress
+		Items    []*testNestedAddress
+	}{a, a, []*testNestedAddress{a, a}}).Validate()
+	var paths []string
+	for _, item := range r.Items {
+		if !item.Passed {
+			paths = append(paths, item.Path)
+		}
+	}
+	expect := []string{"Billing.City", "Shipping.City", "Items[0].City", "Items[1].City"}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Fatalf("test failed: expect %v, but got %v\n", expect, paths)
+	}
+}
+
+func TestValidator_NestedErr(t *testing.T) {
+	err := New(&struct {
+		Address testNestedAddress `validate:"nested(A)"`
+		Items   []struct {
+			Sku string `validate:"minlength(A)"`
+		}
+	}{}).Err()
+	errs, ok := err.(TagErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("test failed: expect 2 TagErrors, but got [%v]\n", err)
+	}
+	if errs[0].Rule != "nested" || errs[1].Rule != "minlength" || errs[1].Field != "Sku" {
+		t.Fatalf("test failed: unexpected errors [%v]\n", errs)
+	}
+	if errs[1].Struct.Kind() != reflect.Struct || errs[1].Struct.NumField() != 1 {
+		t.Fatalf("test failed: expect nested struct type, but got [%v]\n", errs[1].Struct)
+	}
+}
+
+type testPathOrder struct {
+	testNestedEmbed
+	Items []testPathItem          `json:"items"`
+	Meta  map[string]testPathItem `json:"meta"`
+	Name  string                  `json:"name" validate:"minlength(1)"`
+}
+
+type testPathItem struct {
+	Sku  string             `json:"sku" validate:"minlength(1)"`
+	Next *testNestedAddress `json:"next"`
+}
+
+func TestValidator_Path(t *testing.T) {
+	order := &testPathOrder{
+		Items: []testPathItem{{Sku: "a"}, {Sku: "b", Next: &testNestedAddress{}}, {}},
+		Meta:  map[string]testPathItem{"region": {}},
+	}
+	for _, tc := range []struct {
+		name     string
+		nameFunc NameFunc
+		path     string
+		paths    []string
+	}{
+		{"Default", nil, "", []string{"ID", "Items[1].Next.City", "Items[2].Sku", `Meta["region"].Sku`, "Name"}},
+		{"GoName", GoName, "", []string{"ID", "Items[1].Next.City", "Items[2].Sku", `Meta["region"].Sku`, "Name"}},
+		{"JSONName", JSONName, "", []string{"ID", "items[1].next.City", "items[2].sku", `meta["region"].sku`, "name"}},
+		{"Custom", func(field reflect.StructField) string { return "_" + field.Name }, "", []string{"_testNestedEmbed._ID", "_Items[1]._Next._City", "_Items[2]._Sku", `_Meta["region"]._Sku`, "_Name"}},
+		{"Prefixed", nil, "Orders[0]", []string{"Orders[0].ID", "Orders[0].Items[1].Next.City", "Orders[0].Items[2].Sku", `Orders[0].Meta["region"].Sku`, "Orders[0].Name"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(order).NameFunc(tc.nameFunc).Path(tc.path).Validate()
+			paths := make([]string, 0)
+			for _, item := range r.Items {
+				if !item.Passed {
+					paths = append(paths, item.Path)
+				}
+			}
+			if !reflect.DeepEqual(paths, tc.paths) {
+				t.Fatalf("%s test failed: expect paths %q, but got %q\n", tc.name, tc.paths, paths)
+			}
+		})
+	}
+}
+
+func TestValidator_Cache(t *testing.T) {
+	type cached struct {
+		Name  string `validate:"minlength(1) regex(^[a-z]+$)"`
+		Items []testNestedItem
+	}
+	v1, v2 := New(&cached{}), New(&cached{Name: "a"})
+	if v1.schema != v2.schema {
+		t.Fatal("test failed: expect the same cached schema")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := New(&cached{Name: "a", Items: []testNestedItem{{"a"}, {}}}).Validate()
+			if r.Passed || r.Messages() != "sku" {
+				t.Errorf("test failed: expect [false sku], but got [%v %s]\n", r.Passed, r.Messages())
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+type benchOrder struct {
+	Name  string   `validate:"minlength(1) maxlength(32) regex(^[a-zA-Z ]+$)"`
+	Age   int      `validate:"min(18) max(120)"`
+	Role  string   `validate:"enum(admin|user|guest)"`
+	Tags  []string `validate:"arr_maxlength(8) maxlength(16)"`
+	Items []benchItem
+}
+
+type benchItem struct {
+	Sku      string `validate:"length(8) regex(^[A-Z0-9]+$)"`
+	Quantity uint   `validate:"min(1) max(99)"`
+}
+
+func newBenchOrder() *benchOrder {
+	return &benchOrder{"John Doe", 30, "admin", []string{"a", "b"}, []benchItem{{"ABCD1234", 1}, {"EFGH5678", 2}}}
+}
+
+func BenchmarkValidator_Validate(b *testing.B) {
+	order := newBenchOrder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		New(order).Validate()
+	}
+}
+
+func BenchmarkValidator_ValidateUncached(b *testing.B) {
+	order := newBenchOrder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := &schemaCompiler{root: reflect.TypeOf(order).Elem(), schemas: make(map[reflect.Type]*structSchema)}
+		(&Validator{structPtr: order, schema: c.compile(reflect.TypeOf(order).Elem())}).Validate()
+	}
+}
+
+func TestValidator_Modes(t *testing.T) {
+	type mode struct {
+		Name  string `validate:"minlength(3,short) regex(^[a-z]+$,lower) maxlength(1,long)"`
+		Age   int    `validate:"min(18,young)"`
+		Items []testNestedItem
+	}
+	value := &mode{Name: "AB", Items: []testNestedItem{{}, {}}}
+	for _, tc := range []struct {
+		name      string
+		firstRule bool
+		failFast  bool
+		paths     []string
+		rules     [][]string
+		msg       string
+	}{
+		{"All", false, false, []string{"Name", "Age", "Items[0].Sku", "Items[1].Sku"},
+			[][]string{{"minlength", "maxlength", "regex"}, {"min"}, {"minlength"}, {"minlength"}}, "short,long,lower,young,sku,sku"},
+		{"FirstRule", true, false, []string{"Name", "Age", "Items[0].Sku", "Items[1].Sku"},
+			[][]string{{"minlength"}, {"min"}, {"minlength"}, {"minlength"}}, "short,young,sku,sku"},
+		{"FailFast", false, true, []string{"Name"},
+			[][]string{{"minlength", "maxlength", "regex"}}, "short,long,lower"},
+		{"FirstRuleAndFailFast", true, true, []string{"Name"},
+			[][]string{{"minlength"}}, "short"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(value).StopOnFirstRule(tc.firstRule).FailFast(tc.failFast).Validate()
+			if r.Passed {
+				t.Fatalf("%s test failed: expect passed [false], but got [%v]\n", tc.name, r.Passed)
+			}
+			paths := make([]string, 0)
+			rules := make([][]string, 0)
+			for _, item := range r.Items {
+				paths = append(paths, item.Path)
+				itemRules := make([]string, 0)
+				for _, e := range item.Errors {
+					itemRules = append(itemRules, e.Rule)
+				}
+				rules = append(rules, itemRules)
+			}
+			if !reflect.DeepEqual(paths, tc.paths) {
+				t.Fatalf("%s test failed: expect paths %q, but got %q\n", tc.name, tc.paths, paths)
+			}
+			if !reflect.DeepEqual(rules, tc.rules) {
+				t.Fatalf("%s test failed: expect rules %q, but got %q\n", tc.name, tc.rules, rules)
+			}
+			if msg := r.Messages(); msg != tc.msg {
+				t.Fatalf("%s test failed: expect msg [%s], but got [%s]\n", tc.name, tc.msg, msg)
+			}
+		})
+	}
+}
+
+func TestValidator_Map(t *testing.T) {
+	type model struct {
+		Labels map[string]int   `validate:"arr_minlength(1) arr_maxlength(3) keys(regex(^[a-z]+$,bad key)) values(min(0))"`
+		Scores *map[int]float64 `validate:"values(max(100))"`
+		Tags   map[string]string
+	}
+	scores := map[int]float64{1: 50, 2: 101}
+	for _, tc := range []struct {
+		name   string
+		m      *model
+		errors map[string][]string
+	}{
+		{"Valid", &model{Labels: map[string]int{"a": 0, "b": 1}}, map[string][]string{}},
+		{"Empty", &model{Labels: map[string]int{}}, map[string][]string{"Labels": {"minsize"}}},
+		{"TooMany", &model{Labels: map[string]int{"a": 0, "b": 1, "c": 2, "d": 3}}, map[string][]string{"Labels": {"maxsize"}}},
+		{"Entries", &model{Labels: map[string]int{"a": -1, "B": 1}, Scores: &scores}, map[string][]string{
+			`Labels["B"]`: {"regex"}, `Labels["a"]`: {"min"}, "Scores[2]": {"max"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(tc.m).Validate()
+			errs := make(map[string][]string)
+			for _, item := range r.Items {
+				for _, fe := range item.Errors {
+					errs[fe.Path] = append(errs[fe.Path], fe.Rule)
+				}
+			}
+			if !reflect.DeepEqual(errs, tc.errors) {
+				t.Fatalf("%s failed: expect %v, but got %v\n", t.Name(), tc.errors, errs)
+			}
+			if r.Passed != (len(errs) == 0) {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), len(errs) == 0, r.Passed)
+			}
+		})
+	}
+	r := New(&model{Labels: map[string]int{"B": 1}}).Validate()
+	if msg := r.Messages(); msg != "bad key" {
+		t.Fatalf("test failed: msg expect [bad key], but got [%v]\n", msg)
+	}
+	if r = New(&model{Labels: map[string]int{"B": -1}}).FailFast(true).Validate(); len(r.Items) != 2 {
+		t.Fatalf("test failed: expect to stop at the key, but got %d items\n", len(r.Items))
+	}
+}
+
+func TestValidator_MapErr(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+		rule      string
+	}{
+		{"MapRule", &struct {
+			M map[string]int `validate:"min(1)"`
+		}{}, "min"},
+		{"NotMap", &struct {
+			S []int `validate:"values(min(1))"`
+		}{}, "values"},
+		{"KeyRule", &struct {
+			M map[string]int `validate:"keys(min(A))"`
+		}{}, "keys.min"},
+		{"ValueType", &struct {
+			M map[string]int `validate:"values(enum(a|b))"`
+		}{}, "values.enum"},
+		{"Unbalanced", &struct {
+			M map[string]int `validate:"values(min(1)"`
+		}{}, "values"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New(tc.structPtr)
+			errs, ok := v.Err().(TagErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("%s failed: expect TagErrors, but got [%v]\n", t.Name(), v.Err())
+			}
+			if te := errs[0]; te.Rule != tc.rule {
+				t.Fatalf("%s failed: rule expect [%s], but got [%s]\n", t.Name(), tc.rule, te.Rule)
+			}
+			if v.Validate().Passed {
+				t.Fatalf("%s failed: expect un-passed\n", t.Name())
+			}
+		})
+	}
+}
+
+func TestValidator_Dive(t *testing.T) {
+	type model struct {
+		Names  []string            `validate:"minsize(1) maxsize(3) dive(minlength(2,short name))"`
+		Matrix [][]int             `validate:"size(2) dive(size(2) dive(min(0) max(9)))"`
+		Groups *[2][]string        `validate:"dive(minsize(1))"`
+		Sets   []map[string]string `validate:"dive(keys(regex(^[a-z]+$)) values(required(T)))"`
+	}
+	for _, tc := range []struct {
+		name   string
+		m      *model
+		errors map[string][]string
+	}{
+		{"Valid", &model{Names: []string{"ab"}, Matrix: [][]int{{0, 1}, {2, 9}}}, map[string][]string{}},
+		{"Container", &model{Matrix: [][]int{{0, 1}}}, map[string][]string{"Names": {"minsize"}, "Matrix": {"size"}}},
+		{"Elements", &model{Names: []string{"ab", "a"}, Matrix: [][]int{{0, 1}, {10}}}, map[string][]string{
+			"Names[1]": {"minlength"}, "Matrix[1]": {"size"}, "Matrix[1][0]": {"max"}}},
+		{"Array", &model{Names: []string{"ab"}, Matrix: [][]int{{0, 1}, {2, 9}}, Groups: &[2][]string{{"a"}}}, map[string][]string{
+			"Groups[1]": {"minsize"}}},
+		{"Maps", &model{Names: []string{"ab"}, Matrix: [][]int{{0, 1}, {2, 9}}, Sets: []map[string]string{{"a": "a"}, {"B": "b", "c": ""}}},
+			map[string][]string{`Sets[1]["B"]`: {"regex"}, `Sets[1]["c"]`: {"required"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(tc.m).Validate()
+			errs := make(map[string][]string)
+			for _, item := range r.Items {
+				for _, fe := range item.Errors {
+					errs[fe.Path] = append(errs[fe.Path], fe.Rule)
+				}
+			}
+			if !reflect.DeepEqual(errs, tc.errors) {
+				t.Fatalf("%s failed: expect %v, but got %v\n", t.Name(), tc.errors, errs)
+			}
+		})
+	}
+	r := New(&model{Names: []string{"a"}}).Validate()
+	if msg := r.Messages(); msg != "short name,Matrix must have 2 elements" {
+		t.Fatalf("test failed: msg expect [short name,Matrix must have 2 elements], but got [%v]\n", msg)
+	}
+	if r = New(&model{Names: []string{"a", "b"}}).FailFast(true).Validate(); len(r.Items) != 2 || r.Items[1].Path != "Names[0]" {
+		t.Fatalf("test failed: expect to stop at Names[0], but got %d items\n", len(r.Items))
+	}
+}
+
+func TestValidator_DiveErr(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		structPtr interface{}
+		rule      string
+	}{
+		{"NotSlice", &struct {
+			S string `validate:"dive(minlength(1))"`
+		}{}, "dive"},
+		{"Map", &struct {
+			M map[string]string `validate:"dive(minlength(1))"`
+		}{}, "dive"},
+		{"ElemRule", &struct {
+			S []string `validate:"minlength(1) dive(maxlength(2))"`
+		}{}, "minlength"},
+		{"Nested", &struct {
+			S [][]int `validate:"dive(dive(min(A)))"`
+		}{}, "dive.dive.min"},
+		{"Unbalanced", &struct {
+			S [][]int `validate:"dive(dive(min(1))"`
+		}{}, "dive"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := New(tc.structPtr)
+			errs, ok := v.Err().(TagErrors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("%s failed: expect TagErrors, but got [%v]\n", t.Name(), v.Err())
+			}
+			if te := errs[0]; te.Rule != tc.rule {
+				t.Fatalf("%s failed: rule expect [%s], but got [%s]\n", t.Name(), tc.rule, te.Rule)
+			}
+		})
+	}
 }
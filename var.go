@@ -0,0 +1,59 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// interfaceType is the type of nil values
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// Var validates a single value against a validate tag, e.g. Var(page, "min(1) max(100)").
+// The value is validated exactly like a struct field named Value tagged with tag, its ResultItem.Path is Value.
+// Malformed tags never panic: the value is reported as un-passed and Result.Err returns them as TagErrors
+func Var(value interface{}, tag string) *Result { return validateVar(tag, value) }
+
+// VarWithValue validates a value against a validate tag like Var, its cross-field rules compare it with other,
+// named Other, e.g. VarWithValue(confirm, password, "eqfield(Other)")
+func VarWithValue(value, other interface{}, tag string) *Result {
+	return validateVar(tag, value, other)
+}
+
+// validateVar validates values[0] as the field Value of a struct, values[1] is the field Other when given
+func validateVar(tag string, values ...interface{}) *Result {
+	fields := make([]reflect.StructField, len(values))
+	for i, value := range values {
+		typ := interfaceType
+		if value != nil {
+			typ = reflect.TypeOf(value)
+		}
+		fields[i] = reflect.StructField{Name: "Value", Type: typ, Tag: reflect.StructTag("validate:" + strconv.Quote(tag))}
+		if i > 0 {
+			fields[i].Name, fields[i].Tag = "Other", ""
+		}
+	}
+	structPtr := reflect.New(reflect.StructOf(fields))
+	for i, value := range values {
+		if value != nil {
+			structPtr.Elem().Field(i).Set(reflect.ValueOf(value))
+		}
+	}
+	v := New(structPtr.Interface())
+	result := v.Validate()
+	result.StructPtr = values[0]
+	if err := v.Err(); err != nil {
+		result.err = err
+	}
+	return result
+}
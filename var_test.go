@@ -0,0 +1,133 @@
+// Copyright 2022 validator Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVar(t *testing.T) {
+	var nilPtr *int
+	now := time.Now()
+	for _, tc := range []struct {
+		name   string
+		value  interface{}
+		tag    string
+		passed bool
+		rules  []string
+	}{
+		{"Pass", 10, "min(1) max(100)", true, nil},
+		{"Min", 0, "min(1) max(100)", false, []string{"min"}},
+		{"String", "ab", "required(T) minlength(3) regex(^[a-z]+$)", false, []string{"minlength"}},
+		{"Nil", nil, "required(T)", false, []string{"required"}},
+		{"NilOmitEmpty", nil, "min(1)", true, nil},
+		{"NilPtr", nilPtr, "required(T)", false, []string{"required"}},
+		{"PtrPtr", &nilPtr, "min(1)", true, nil},
+		{"Slice", []string{"a", ""}, "minsize(1) dive(required(T))", false, []string{"required"}},
+		{"Map", map[string]int{"a": -1}, "values(min(0))", false, []string{"min"}},
+		{"Time", time.Time{}, "required(T)", false, []string{"required"}},
+		{"TimeSet", now, "required(T)", true, nil},
+		{"Struct", &testNestedAddress{}, "valid(T)", false, []string{"minlength"}},
+		{"NoRule", 1, "", true, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Var(tc.value, tc.tag)
+			if r.Passed != tc.passed {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), tc.passed, r.Passed)
+			}
+			var rules []string
+			for _, item := range r.Items {
+				for _, fe := range item.Errors {
+					rules = append(rules, fe.Rule)
+				}
+			}
+			if !reflect.DeepEqual(rules, tc.rules) {
+				t.Fatalf("%s failed: rules expect %v, but got %v\n", t.Name(), tc.rules, rules)
+			}
+			if !reflect.DeepEqual(r.StructPtr, tc.value) {
+				t.Fatalf("%s failed: expect StructPtr to be the value\n", t.Name())
+			}
+		})
+	}
+	if msg := Var("", "required(T)").Messages(); msg != "Value is required" {
+		t.Fatalf("test failed: msg expect [Value is required], but got [%v]\n", msg)
+	}
+	if err := Var(0, "min(1)").Err(); !errors.Is(err, ErrMin) {
+		t.Fatalf("test failed: expect ErrMin, but got [%v]\n", err)
+	}
+}
+
+func TestVarErr(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value interface{}
+		tag   string
+	}{
+		{"Malformed", 1, "min(A)"},
+		{"Type", 1, "enum(a|b)"},
+		{"Unbalanced", 1, "min(1"},
+		{"CrossField", 1, "eqfield(Other)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Var(tc.value, tc.tag)
+			if r.Passed || !errors.Is(r.Err(), ErrTag) {
+				t.Fatalf("%s failed: expect ErrTag, but got [%v]\n", t.Name(), r.Err())
+			}
+		})
+	}
+}
+
+func TestVarWithValue(t *testing.T) {
+	start := time.Now()
+	for _, tc := range []struct {
+		name   string
+		value  interface{}
+		other  interface{}
+		tag    string
+		passed bool
+	}{
+		{"Eq", "a", "a", "eqfield(Other)", true},
+		{"NotEq", "a", "b", "eqfield(Other,differ)", false},
+		{"Gt", start.Add(time.Hour), start, "gtfield(Other)", true},
+		{"NotGt", start, start, "gtfield(Other)", false},
+		{"NumberClasses", 2, 1.5, "gtfield(Other)", true},
+		{"RequiredWith", "", "a", "required_with(Other)", false},
+		{"RequiredWithout", "", "a", "required_without(Other)", true},
+		{"NilOther", 1, nil, "required(T)", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := VarWithValue(tc.value, tc.other, tc.tag)
+			if err := r.Err(); errors.Is(err, ErrTag) {
+				t.Fatalf("%s failed: unexpected err [%v]\n", t.Name(), err)
+			}
+			if r.Passed != tc.passed {
+				t.Fatalf("%s failed: passed expect [%v], but got [%v]\n", t.Name(), tc.passed, r.Passed)
+			}
+		})
+	}
+	if err := VarWithValue("a", 1, "eqfield(Other)").Err(); !errors.Is(err, ErrTag) {
+		t.Fatalf("test failed: expect incomparable ErrTag, but got [%v]\n", err)
+	}
+	if msg := VarWithValue("a", "b", "eqfield(Other)").Messages(); msg != "Value must be equal to Other" {
+		t.Fatalf("test failed: unexpected msg [%v]\n", msg)
+	}
+}
+
+func BenchmarkVar(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		Var("john", "required(T) minlength(2) maxlength(32)")
+	}
+}